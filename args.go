@@ -3,8 +3,10 @@ package dbsample
 import (
 	"bytes"
 	"fmt"
+	"github.com/go-sql-driver/mysql"
 	"github.com/howeyc/gopass"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"net"
 	"os"
 	"os/user"
 	"regexp"
@@ -16,27 +18,62 @@ const (
 	DriverMySQL = "mysql"
 )
 
+const (
+	ProtocolTCP    = "tcp"
+	ProtocolSocket = "socket"
+	ProtocolPipe   = "pipe"
+	ProtocolMemory = "memory"
+)
+
 // ConnectionArgs...
 type ConnectionArgs struct {
-	Driver   string
-	Name     string
-	Host     string
-	Port     string
-	User     string
-	Pass     string
-	Protocol string
+	Driver        string
+	Name          string
+	Host          string
+	Port          string
+	User          string
+	Pass          string
+	Protocol      string
+	Socket        string
+	DSN           string
+	SSLMode       string
+	SSLCA         string
+	SSLCert       string
+	SSLKey        string
+	SSHHost       string
+	SSHUser       string
+	SSHKey        string
+	SSHKnownHosts string
+	// tls is the value of the tls DSN parameter set by setupTLS.
+	tls string
 }
 
 func (c *ConnectionArgs) dsn() string {
-	return fmt.Sprintf(
-		"%s:%s@%s(%s:%s)/%s",
+	if c.DSN != "" {
+		return c.DSN
+	}
+	network, addr := "tcp", net.JoinHostPort(c.Host, c.Port)
+	switch {
+	case c.SSHHost != "":
+		network = sshNetwork
+	case c.Protocol == ProtocolSocket:
+		network, addr = "unix", c.Socket
+	}
+	dsn := fmt.Sprintf(
+		"%s:%s@%s(%s)/%s",
 		c.User,
 		c.Pass,
-		c.Protocol,
-		c.Host,
-		c.Port,
+		network,
+		addr,
 		c.Name,
 	)
+	// Sampled values are bound to a placeholder each, so they are interpolated
+	// by the driver rather than prepared, which is limited to 65535 of them.
+	dsn += "?collation=" + mysql5ConnectionCollation + "&interpolateParams=true"
+	if c.tls != "" {
+		dsn += "&tls=" + c.tls
+	}
+	return dsn
 }
 
 // DumpArgs...
@@ -44,13 +81,34 @@ type DumpArgs struct {
 	Limit            int
 	Routines         bool
 	Triggers         bool
+	Views            bool
 	RenameDatabase   string
 	NoCreateDatabase bool
 	SkipLockTables   bool
+	Threads          int
 	SkipAddDropTable bool
 	ExtendedInsert   bool
-	Filters          []string
-	Constraints      map[string][]*Constraint
+	HexBlob          bool
+	ResultFile       string
+	Compress         string
+	Format           string
+	OutputDir        string
+	CSVNull          string
+	Target           *ConnectionArgs
+	Plan             bool
+	Graph            bool
+	GraphFormat      string
+	// DumpFile is the dump file checked by the verify command, which reads no
+	// database.
+	DumpFile      string
+	ScanPII       bool
+	FailOnPII     bool
+	Verify        bool
+	FailOnOrphans bool
+	PolicyWarn    bool
+	Policy        *Policy
+	Filters       []string
+	Constraints   map[string][]*Constraint
 }
 
 // ParseFlags parses the command line flags.
@@ -71,37 +129,108 @@ func ParseFlags() (*ConnectionArgs, *DumpArgs, error) {
 	}
 
 	kingpin.Version(Version)
-	kingpin.Flag("host", "The database host.").Default("127.0.0.1").Short('h').StringVar(&conn.Host)
-	kingpin.Flag("port", "The database port.").Default("3306").Short('P').StringVar(&conn.Port)
-	kingpin.Flag("protocol", "The protocol to use for the connection (tcp, socket, pip, memory).").Default("tcp").StringVar(&conn.Protocol)
+	kingpin.Flag("host", "The database host (default 127.0.0.1).").Short('h').StringVar(&conn.Host)
+	kingpin.Flag("port", "The database port (default 3306).").Short('P').StringVar(&conn.Port)
+	kingpin.Flag("protocol", "The protocol to use for the connection (tcp, socket). Defaults to socket when --socket is given or the host is localhost, and tcp otherwise.").StringVar(&conn.Protocol)
+	kingpin.Flag("socket", "The Unix socket file to connect to, e.g. /var/run/mysqld/mysqld.sock.").Short('S').PlaceHolder("FILE").StringVar(&conn.Socket)
 	kingpin.Flag("user", "User for login if not current user.").Short('u').StringVar(&conn.User)
 	kingpin.Flag("password", "Password to use when connecting to server. If password is not given it's asked from stderr.").Short('p').StringVar(&conn.Pass)
+	passwordFile := kingpin.Flag("password-file", "Read the password from the first line of this file.").PlaceHolder("FILE").String()
+	defaultsFile := kingpin.Flag("defaults-file", "Read options only from this option file.").PlaceHolder("FILE").String()
+	defaultsExtraFile := kingpin.Flag("defaults-extra-file", "Read this option file after the global option files and before ~/.my.cnf.").PlaceHolder("FILE").String()
+	noDefaults := kingpin.Flag("no-defaults", "Do not read any option files.").Bool()
+	kingpin.Flag("ssl-mode", "The security state of the connection (disabled, preferred, required, verify-ca, verify-identity).").PlaceHolder("MODE").StringVar(&conn.SSLMode)
+	kingpin.Flag("ssl-ca", "The file of the certificate authority which signed the server certificate.").PlaceHolder("FILE").StringVar(&conn.SSLCA)
+	kingpin.Flag("ssl-cert", "The file of the client certificate.").PlaceHolder("FILE").StringVar(&conn.SSLCert)
+	kingpin.Flag("ssl-key", "The file of the client certificate key.").PlaceHolder("FILE").StringVar(&conn.SSLKey)
+	kingpin.Flag("ssh-host", "Connect to the database through an SSH tunnel to this host, e.g. bastion.example.com:22.").PlaceHolder("HOST[:PORT]").StringVar(&conn.SSHHost)
+	kingpin.Flag("ssh-user", "User for the SSH login if not current user.").PlaceHolder("USER").StringVar(&conn.SSHUser)
+	kingpin.Flag("ssh-key", "The private key file for the SSH login. Keys in the SSH agent are also tried.").PlaceHolder("FILE").StringVar(&conn.SSHKey)
+	kingpin.Flag("ssh-known-hosts", "The known hosts file the SSH host key is verified against (default ~/.ssh/known_hosts).").PlaceHolder("FILE").StringVar(&conn.SSHKnownHosts)
 	kingpin.Flag("debug", "").Hidden().BoolVar(&IsDebugging)
 	kingpin.Flag("routines", "Dump procedures and functions.").BoolVar(&args.Routines)
 	kingpin.Flag("triggers", "Dump triggers.").BoolVar(&args.Triggers)
+	kingpin.Flag("views", "Dump views.").BoolVar(&args.Views)
 	kingpin.Flag("limit", "Max number of rows from each table to dump.").Default("100").Short('l').IntVar(&args.Limit)
 	kingpin.Flag("no-create-database", "Disable adding CREATE DATABASE statement.").Short('n').BoolVar(&args.NoCreateDatabase)
 	kingpin.Flag("skip-lock-tables", "Disable locking tables on read.").BoolVar(&args.SkipLockTables)
+	kingpin.Flag("threads", "Select the rows of up to N independent tables at the same time, each on its own connection.").Short('t').Default("1").PlaceHolder("N").IntVar(&args.Threads)
 	kingpin.Flag("skip-add-drop-table", "Disable adding DROP TABLE statements.").BoolVar(&args.SkipAddDropTable)
 	kingpin.Flag("extended-insert", "Use multiple-row INSERT syntax that include several VALUES lists.").BoolVar(&args.ExtendedInsert)
+	kingpin.Flag("hex-blob", "Dump binary columns (BINARY, VARBINARY, BLOB) using hexadecimal notation. Use --no-hex-blob to disable.").Default("true").BoolVar(&args.HexBlob)
 	kingpin.Flag("rename-database", "Use this database name in the dump.").PlaceHolder("DUMP-NAME").StringVar(&args.RenameDatabase)
+	kingpin.Flag("result-file", "Write the dump to this file instead of stdout.").Short('r').PlaceHolder("FILE").StringVar(&args.ResultFile)
+	kingpin.Flag("compress", "Compress the dump (auto, none, gzip, zstd). Auto chooses by the result file extension, e.g. .sql.gz or .sql.zst.").Default(CompressAuto).EnumVar(&args.Compress, CompressAuto, CompressNone, CompressGzip, CompressZstd)
+	kingpin.Flag("format", "The output format (sql, dir, tab, csv, jsonl, parquet). Formats other than sql write one file per table to --output-dir.").Default(FormatSQL).EnumVar(&args.Format, FormatSQL, FormatDir, FormatTab, FormatCSV, FormatJSONL, FormatParquet)
+	kingpin.Flag("output-dir", "Write the dump files to this directory.").PlaceHolder("DIR").StringVar(&args.OutputDir)
+	kingpin.Flag("csv-null", "Write NULL values as this string in CSV files.").Default(`\N`).StringVar(&args.CSVNull)
+	kingpin.Flag("graph-format", "The format of the graph command output (dot, mermaid).").Default(GraphFormatDOT).EnumVar(&args.GraphFormat, GraphFormatDOT, GraphFormatMermaid)
+	targetDSN := kingpin.Flag("target-dsn", "Load the sample directly into the database of this DSN instead of writing a dump, e.g. user:pass@tcp(localhost:3306)/dev_db.").PlaceHolder("DSN").String()
 	fks := kingpin.Flag("constraint", "Assigns one or more foreign key constraints.").Short('c').Strings()
 	kingpin.Flag("filter", "Apply a filter to the output.").Short('f').StringsVar(&args.Filters)
-	kingpin.Arg("database", "Name of the database to dump.").Required().StringVar(&conn.Name)
-	kingpin.Parse()
+	kingpin.Flag("scan-pii", "Report unfiltered columns which may contain personal data.").BoolVar(&args.ScanPII)
+	kingpin.Flag("fail-on-pii", "Fail instead of dumping when the PII scan finds unfiltered columns.").BoolVar(&args.FailOnPII)
+	kingpin.Flag("verify", "Report sampled rows whose foreign key values are missing from the sample.").BoolVar(&args.Verify)
+	kingpin.Flag("fail-on-orphans", "Fail instead of dumping when verification finds orphaned rows.").BoolVar(&args.FailOnOrphans)
+	policy := kingpin.Flag("policy", "Require every column to be classified by the policy file.").PlaceHolder("FILE").String()
+	kingpin.Flag("policy-warn", "Warn instead of failing when columns break the policy.").BoolVar(&args.PolicyWarn)
+	// Every command takes the flags of a dump, which are defined on the app,
+	// and dump is the default so "dbsample blog" dumps blog.
+	dumpCmd := kingpin.Command("dump", "Dump a sample of the database (default).").Default()
+	dumpCmd.Arg("database", "Name of the database to dump.").Required().StringVar(&conn.Name)
+	planCmd := kingpin.Command("plan", "Print what a dump of the database would do without reading any rows.")
+	planCmd.Arg("database", "Name of the database to plan.").Required().StringVar(&conn.Name)
+	graphCmd := kingpin.Command("graph", "Print the foreign keys between the tables of the database as a graph.")
+	graphCmd.Arg("database", "Name of the database to graph.").Required().StringVar(&conn.Name)
+	verifyCmd := kingpin.Command("verify", "Restore a SQL dump in memory and check it against the rows it recorded.")
+	verifyCmd.Arg("dump-file", "The dump file, which may be compressed with gzip or zstd.").Required().StringVar(&args.DumpFile)
+	switch kingpin.Parse() {
+	case planCmd.FullCommand():
+		args.Plan = true
+	case graphCmd.FullCommand():
+		args.Graph = true
+	case verifyCmd.FullCommand():
+		// Verifying reads the dump file only, so no connection is set up.
+		return conn, args, nil
+	}
 
-	if conn.User == "" {
-		u, err := user.Current()
+	if *passwordFile != "" {
+		if conn.Pass != "" {
+			return nil, nil, fmt.Errorf("The --password and --password-file flags cannot be used together")
+		}
+		pass, err := readPasswordFile(*passwordFile)
 		if err != nil {
 			return nil, nil, err
 		}
-		conn.User = u.Username
+		conn.Pass = pass
+	} else if conn.Pass != "" && conn.Pass != "\000" {
+		warning("Warning: Using a password on the command line interface can be insecure.")
+	}
+	opts := map[string]string{}
+	if !*noDefaults {
+		files := defaultOptionFiles(*defaultsExtraFile)
+		required := map[string]bool{*defaultsExtraFile: true}
+		if *defaultsFile != "" {
+			files = []string{*defaultsFile}
+			required = map[string]bool{*defaultsFile: true}
+		}
+		var err error
+		if opts, err = readOptionFiles(files, required); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := setConnectionOptions(conn, opts, os.Getenv); err != nil {
+		return nil, nil, err
+	}
+	if err := conn.setupTLS(); err != nil {
+		return nil, nil, err
+	}
+	if err := conn.setupSSH(); err != nil {
+		return nil, nil, err
 	}
 	if conn.Pass == "\000" {
 		pass, _ := gopass.GetPasswdPrompt("Enter password: ", false, os.Stdin, os.Stderr)
 		conn.Pass = string(pass)
-	} else {
-		warning("Warning: Using a password on the command line interface can be insecure.")
 	}
 
 	r := regexp.MustCompile(`([\w]+)\.([\w]+)\s+([\w]+)\.([\w]+)`)
@@ -117,15 +246,113 @@ func ParseFlags() (*ConnectionArgs, *DumpArgs, error) {
 			TableName:            m[3],
 			ColumnName:           m[4],
 			ReferencedColumnName: m[2],
+			UserDefined:          true,
 		})
 	}
 	if err := Filters.SetCommands(args.Filters); err != nil {
 		return nil, nil, err
 	}
+	if args.Threads < 1 {
+		return nil, nil, fmt.Errorf("The number of threads must be at least 1")
+	}
+	if args.Format != FormatSQL && args.OutputDir == "" {
+		return nil, nil, fmt.Errorf("The %s format requires --output-dir", args.Format)
+	}
+	if args.Format != FormatSQL && args.ResultFile != "" {
+		return nil, nil, fmt.Errorf("The %s format writes to --output-dir and cannot be used with --result-file", args.Format)
+	}
+	if args.Format == FormatTab && args.Compress != CompressAuto && args.Compress != CompressNone {
+		return nil, nil, fmt.Errorf("The tab format cannot be compressed, LOAD DATA reads uncompressed files")
+	}
+	if *targetDSN != "" {
+		if args.Format != FormatSQL || args.ResultFile != "" {
+			return nil, nil, fmt.Errorf("The --target-dsn flag cannot be used with --format or --result-file")
+		}
+		target, err := parseTargetDSN(*targetDSN)
+		if err != nil {
+			return nil, nil, err
+		}
+		args.Target = target
+	}
+	if *policy != "" {
+		p, err := LoadPolicy(*policy)
+		if err != nil {
+			return nil, nil, err
+		}
+		args.Policy = p
+	}
 
 	return conn, args, nil
 }
 
+// setConnectionOptions sets the connection options which were not given on the
+// command line. Like the mysql client, options are taken from the option files
+// first, then the MYSQL_HOST, MYSQL_TCP_PORT, MYSQL_UNIX_PORT and MYSQL_PWD
+// environment variables, and last the defaults, with the user defaulting to the
+// current system user. The protocol is validated, and defaults to socket when
+// --socket is given, or the host is localhost and a socket is known.
+func setConnectionOptions(conn *ConnectionArgs, opts map[string]string, getenv func(string) string) error {
+	first := func(values ...string) string {
+		for _, v := range values {
+			if v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	conn.Host = first(conn.Host, opts["host"], getenv("MYSQL_HOST"), "127.0.0.1")
+	conn.Port = first(conn.Port, opts["port"], getenv("MYSQL_TCP_PORT"), "3306")
+	socket := conn.Socket != ""
+	conn.Socket = first(conn.Socket, opts["socket"], getenv("MYSQL_UNIX_PORT"))
+	if conn.Protocol == "" && opts["protocol"] == "" && (socket || conn.Host == "localhost" && conn.Socket != "") {
+		conn.Protocol = ProtocolSocket
+	}
+	conn.Protocol = strings.ToLower(first(conn.Protocol, opts["protocol"], ProtocolTCP))
+	switch conn.Protocol {
+	case ProtocolTCP:
+	case ProtocolSocket:
+		if conn.Socket == "" {
+			return fmt.Errorf("The socket protocol requires --socket")
+		}
+	case ProtocolPipe, ProtocolMemory:
+		return fmt.Errorf("The %s protocol is not supported, use tcp or socket", conn.Protocol)
+	default:
+		return fmt.Errorf(`Invalid protocol "%s". Must be tcp or socket`, conn.Protocol)
+	}
+	conn.Pass = first(conn.Pass, opts["password"], getenv("MYSQL_PWD"))
+	conn.User = first(conn.User, opts["user"])
+	conn.SSLMode = first(conn.SSLMode, opts["ssl-mode"])
+	conn.SSLCA = first(conn.SSLCA, opts["ssl-ca"])
+	conn.SSLCert = first(conn.SSLCert, opts["ssl-cert"])
+	conn.SSLKey = first(conn.SSLKey, opts["ssl-key"])
+	if conn.User == "" {
+		u, err := user.Current()
+		if err != nil {
+			return err
+		}
+		conn.User = u.Username
+	}
+	return nil
+}
+
+// parseTargetDSN returns the connection to the database named in the dsn.
+func parseTargetDSN(dsn string) (*ConnectionArgs, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DBName == "" {
+		return nil, fmt.Errorf("The target DSN must include a database name")
+	}
+	return &ConnectionArgs{
+		Driver:   DriverMySQL,
+		Name:     cfg.DBName,
+		User:     cfg.User,
+		Protocol: cfg.Net,
+		DSN:      dsn,
+	}, nil
+}
+
 // argsSetupUsage...
 func argsSetupUsageTemplate() error {
 	t, err := template.New("dbsample").Parse(argsUsageDBSample)
@@ -202,6 +429,44 @@ Commands:
 `
 
 var argsUsageDBSample = `
+Option files:
+Connection options which are not given on the command line are read from the
+[client] and [dbsample] groups of /etc/my.cnf, /etc/mysql/my.cnf, the
+--defaults-extra-file and ~/.my.cnf, in that order, or only from --defaults-file.
+The host, port, socket, user, password, protocol and ssl options are used, and !include and
+!includedir directives are followed. Options are taken from, in order of precedence:
+
+  1. the command line, including --password-file and the -p prompt
+  2. option files, where later files override earlier ones
+  3. the MYSQL_HOST, MYSQL_TCP_PORT, MYSQL_UNIX_PORT and MYSQL_PWD environment
+     variables
+  4. the defaults, 127.0.0.1:3306 over tcp as the current system user
+
+The protocol defaults to socket when --socket is given on the command line, or when
+the host is localhost and a socket is set in an option file or MYSQL_UNIX_PORT.
+The pipe and memory protocols of the Windows mysql client are not supported.
+
+TLS:
+The --ssl-mode flag, or the ssl-mode option in an option file, sets how the
+connection is encrypted. It defaults to verify-ca when --ssl-ca is given, required
+when --ssl-cert is given, and disabled otherwise.
+
+  disabled          An unencrypted connection.
+  preferred         Encrypted when the server supports it, without verifying the
+                    server certificate. With --ssl-ca it is verify-ca, and it
+                    cannot be used with --ssl-cert alone.
+  required          Encrypted, without verifying the server certificate.
+  verify-ca         Encrypted, and the server certificate must be signed by --ssl-ca.
+  verify-identity   As verify-ca, and the certificate must also match --host. The
+                    system roots are used when --ssl-ca is not given.
+
+SSH tunnel:
+The --ssh-host flag connects to the database through an SSH server, such as a
+bastion host, with --host and --port resolved from the SSH server. The host key of
+the SSH server must be in --ssh-known-hosts, ~/.ssh/known_hosts by default, and
+keys are taken from the SSH agent when SSH_AUTH_SOCK is set and from --ssh-key,
+which is asked for its passphrase when it is encrypted.
+
 Filters:
 Filters alter column values in the dump. For example they can remove passwords or
 other sensitive information. Each --filter flag should be passed the name of the
@@ -211,10 +476,53 @@ or more arguments.
 {{range .FilterUsages}}  {{.}}
 {{end}}
 
+The hash filter uses a random salt for each run unless a salt is given, which
+keeps the hashes stable across runs.
+
+Plan:
+Run "dbsample plan" with the same flags as a dump to print the order the tables
+would be sampled in, their foreign keys, estimated row counts, and the SELECT each
+table would run, without reading any rows.
+
+Graph:
+Run "dbsample graph" to print the foreign keys between the tables as a Graphviz
+DOT graph, or a Mermaid ER diagram with --graph-format=mermaid. Constraints given
+with --constraint, self references, circular dependencies, and tables sampling
+would skip are marked.
+
+Verifying a dump:
+Run "dbsample verify <dump-file>" to check a SQL dump without a server. Every
+statement is split the way the mysql client does and executed in an in-memory
+MySQL compatible engine (go-mysql-server). The SELECT COUNT(*) and checksum of
+the rows of each restored table are then compared with those the dump recorded
+when it was written, which catches truncated, hand edited or corrupted dumps.
+Compressed .gz and .zst dumps are read directly. The engine does not support
+everything MySQL does, so a statement it rejects may still load into a server.
+
 Examples:
 dbsample --limit=100 blog > dump.sql
 dbsample --limit=100 -h db1 -u admin -p blog > dump.sql
+dbsample --limit=100 --socket=/var/run/mysqld/mysqld.sock blog > dump.sql
+dbsample --limit=100 --defaults-file=ci.cnf --password-file=/run/secrets/db blog > dump.sql
+dbsample --limit=100 -h db.example.com --ssl-mode=verify-identity --ssl-ca=ca.pem blog > dump.sql
+dbsample --limit=100 --ssh-host=bastion.example.com --ssh-key ~/.ssh/id_ed25519 -h replica.internal blog > dump.sql
 dbsample --limit=100 --rename-database=blog_dev blog > dump.sql
+dbsample --limit=100 --result-file=dump.sql.gz blog
+dbsample --limit=100 --format=dir --output-dir=dump blog
+dbsample --limit=100 --format=tab --output-dir=dump blog && cd dump && mysql --local-infile=1 < load.sql
+dbsample --limit=100 --format=parquet --output-dir=export blog
+dbsample --limit=100 --threads=4 blog > dump.sql
+dbsample plan --limit=100 -c "posts.user_id users.id" blog
+dbsample graph blog | dot -Tsvg > blog.svg
+dbsample graph --graph-format=mermaid blog > blog.mmd
+dbsample verify dump.sql.gz
+dbsample --limit=100 --target-dsn="root@tcp(localhost:3306)/blog_dev" blog
 dbsample --limit=100 --filter="empty users.password" --filter="repeat users.email X" blog > dump.sql
+dbsample --limit=100 --filter="dateshift users.birthday id 365" --filter="dateshift orders.created_at user_id 365" blog > dump.sql
+dbsample --limit=100 --filter="noise employees.salary 5%" --filter="bucket employees.salary 1000" blog > dump.sql
+dbsample --limit=100 --filter="json users.profile $.phone repeat X" blog > dump.sql
+dbsample --limit=100 --fail-on-pii --filter="empty users.email" blog > dump.sql
+dbsample --limit=100 --fail-on-orphans -c "posts.user_id users.id" blog > dump.sql
+dbsample --limit=100 --policy=columns.policy --filter="empty users.email" blog > dump.sql
 dbsample --limit=100 -c "posts.user_id users.id" -c "posts.cat_id categories.id" blog > dump.sql
 `
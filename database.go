@@ -1,5 +1,7 @@
 package dbsample
 
+import "io"
+
 // Database queries a database.
 type Database interface {
 	Name() string
@@ -12,4 +14,10 @@ type Database interface {
 	Views() (ViewGraph, error)
 	Routines() (RoutineGraph, error)
 	Server() *Server
+	// Plan writes what a dump of the database would do without reading any
+	// rows.
+	Plan(w io.Writer) error
+	// Graph writes the tables of the database and the foreign keys between
+	// them in the given graph format.
+	Graph(w io.Writer, format string) error
 }
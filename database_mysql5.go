@@ -2,16 +2,105 @@ package dbsample
 
 import (
 	"bytes"
+	"context"
 	gosql "database/sql"
+	"encoding/hex"
 	"fmt"
 	"github.com/deckarep/golang-set"
+	"github.com/headzoo/dbsample/filters"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"os"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 var mysql5RegexpAI = regexp.MustCompile(`AUTO_INCREMENT=[\d]+ `)
 var mysql5Stmts *MySQL5PreparedStatements
 
+// mysql5UniqueRetries is the number of times duplicate values in unique indexes
+// are filtered again before falling back to adding a suffix.
+const mysql5UniqueRetries = 10
+
+// The character set and collation of the connection. Schema objects are read
+// in them, and so are table rows unless a dump format reads them as binary.
+const (
+	mysql5ConnectionCharSet   = "utf8mb4"
+	mysql5ConnectionCollation = "utf8mb4_general_ci"
+)
+
+// mysql5TextCharSets are the column character sets whose values are valid in
+// the connection character set, and are dumped as quoted strings. Values of
+// columns in any other character set are dumped as hexadecimal literals with
+// an introducer, which the server stores byte for byte.
+var mysql5TextCharSets = map[string]bool{
+	"ascii":   true,
+	"utf8":    true,
+	"utf8mb3": true,
+	"utf8mb4": true,
+}
+
+// mysql5CharSetEncodings are the encodings of the column character sets other
+// than the mysql5TextCharSets. Values read as binary are decoded to UTF-8
+// before they are filtered, and filtered values are encoded back.
+var mysql5CharSetEncodings = map[string]encoding.Encoding{
+	"latin1":   charmap.Windows1252,
+	"latin2":   charmap.ISO8859_2,
+	"latin5":   charmap.ISO8859_9,
+	"latin7":   charmap.ISO8859_13,
+	"greek":    charmap.ISO8859_7,
+	"hebrew":   charmap.ISO8859_8,
+	"koi8r":    charmap.KOI8R,
+	"koi8u":    charmap.KOI8U,
+	"cp850":    charmap.CodePage850,
+	"cp852":    charmap.CodePage852,
+	"cp866":    charmap.CodePage866,
+	"cp1250":   charmap.Windows1250,
+	"cp1251":   charmap.Windows1251,
+	"cp1256":   charmap.Windows1256,
+	"cp1257":   charmap.Windows1257,
+	"macroman": charmap.Macintosh,
+	"sjis":     japanese.ShiftJIS,
+	"cp932":    japanese.ShiftJIS,
+	"ujis":     japanese.EUCJP,
+	"eucjpms":  japanese.EUCJP,
+	"euckr":    korean.EUCKR,
+	"gb2312":   simplifiedchinese.GBK,
+	"gbk":      simplifiedchinese.GBK,
+	"gb18030":  simplifiedchinese.GB18030,
+	"big5":     traditionalchinese.Big5,
+	"ucs2":     unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"utf16":    unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM),
+	"utf16le":  unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+}
+
+// mysql5BinaryTypes are the data types which are dumped as hexadecimal
+// literals with --hex-blob.
+var mysql5BinaryTypes = map[string]bool{
+	"binary":     true,
+	"varbinary":  true,
+	"tinyblob":   true,
+	"blob":       true,
+	"mediumblob": true,
+	"longblob":   true,
+}
+
+// mysql5StringTypes are the data types which may be given a suffix.
+var mysql5StringTypes = map[string]bool{
+	"char":       true,
+	"varchar":    true,
+	"tinytext":   true,
+	"text":       true,
+	"mediumtext": true,
+	"longtext":   true,
+}
+
 // MySQL5PreparedStatements...
 type MySQL5PreparedStatements struct {
 	db    *gosql.DB
@@ -109,7 +198,7 @@ func (db *MySQL5Database) Collation() string {
 // CreateSQL...
 func (db *MySQL5Database) CreateSQL() (string, error) {
 	if db.createSQL == "" {
-		rows, err := db.server.query("SHOW CREATE DATABASE %s", MySQL5Backtick(db.name))
+		rows, err := db.server.query("SHOW CREATE DATABASE " + MySQL5Backtick(db.name))
 		if err != nil {
 			return "", err
 		}
@@ -136,12 +225,67 @@ func (db *MySQL5Database) SetCreateSQL(sql string) {
 
 // Tables...
 func (db *MySQL5Database) Tables() (tables TableGraph, err error) {
+	if tables, err = db.loadTables(); err != nil {
+		return
+	}
+	if err = resolveTableRows(tables, db.server.args.Threads, db.queryTableRows); err != nil {
+		return
+	}
+	if err = db.applyFilters(tables); err != nil {
+		return
+	}
+	if db.server.args.ScanPII || db.server.args.FailOnPII {
+		findings := scanPII(tables)
+		writePIIReport(os.Stderr, findings)
+		if db.server.args.FailOnPII && len(findings) > 0 {
+			err = fmt.Errorf("PII scan found %d unfiltered column(s), refusing to dump.", len(findings))
+			return
+		}
+	}
+	if db.server.args.Verify || db.server.args.FailOnOrphans {
+		findings := verifyReferences(tables)
+		writeVerifyReport(os.Stderr, findings)
+		if db.server.args.FailOnOrphans && len(findings) > 0 {
+			err = fmt.Errorf("Verify found orphaned rows in %d foreign key(s), refusing to dump.", len(findings))
+			return
+		}
+	}
+	if db.server.args.Triggers {
+		for _, table := range tables {
+			if err = db.setTableTriggers(table); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// loadTables returns the tables of the database, sorted by their constraints,
+// without their rows.
+func (db *MySQL5Database) loadTables() (tables TableGraph, err error) {
+	if tables, err = db.queryTables(); err != nil {
+		return
+	}
+	if err = db.checkPolicy(tables); err != nil {
+		return
+	}
+	if tables, err = resolveTableConstraints(tables); err != nil {
+		return
+	}
+	return
+}
+
+// queryTables returns the tables of the database with their columns, indexes
+// and constraints.
+func (db *MySQL5Database) queryTables() (tables TableGraph, err error) {
 	mysql5Stmts.Prepare(
 		"Tables",
-		"SELECT `TABLE_NAME`, `TABLE_COLLATION` "+
-			"FROM `INFORMATION_SCHEMA`.`TABLES` "+
-			"WHERE `TABLE_SCHEMA` = ? "+
-			"AND `TABLE_TYPE` = 'BASE TABLE'",
+		"SELECT `T`.`TABLE_NAME`, `T`.`TABLE_COLLATION`, `C`.`CHARACTER_SET_NAME`, `T`.`TABLE_ROWS` "+
+			"FROM `INFORMATION_SCHEMA`.`TABLES` AS `T` "+
+			"LEFT JOIN `INFORMATION_SCHEMA`.`COLLATION_CHARACTER_SET_APPLICABILITY` AS `C` "+
+			"ON `C`.`COLLATION_NAME` = `T`.`TABLE_COLLATION` "+
+			"WHERE `T`.`TABLE_SCHEMA` = ? "+
+			"AND `T`.`TABLE_TYPE` = 'BASE TABLE'",
 	)
 	var rows *gosql.Rows
 	if rows, err = mysql5Stmts.Query("Tables", db.Name()); err != nil {
@@ -152,47 +296,42 @@ func (db *MySQL5Database) Tables() (tables TableGraph, err error) {
 	tables = make(TableGraph, 0)
 	for rows.Next() {
 		table := NewTable()
-		if err = rows.Scan(&table.Name, &table.Collation); err != nil {
+		estimate := gosql.NullInt64{}
+		collation := gosql.NullString{}
+		charSet := gosql.NullString{}
+		if err = rows.Scan(&table.Name, &collation, &charSet, &estimate); err != nil {
 			return
 		}
+		table.CharSet, table.Collation = db.charSet, db.collation
+		if charSet.Valid && collation.Valid {
+			table.CharSet, table.Collation = charSet.String, collation.String
+		}
+		table.EstimatedRows = estimate.Int64
 		if err = db.setTableConstraints(table); err != nil {
 			return
 		}
 		if err = db.setTableCreateSQL(table); err != nil {
 			return
 		}
+		if err = db.setTableIndexes(table); err != nil {
+			return
+		}
 		var cols ColumnMap
 		if cols, err = db.tableColumns(table.Name); err != nil {
 			return
 		}
 		table.Columns = cols
-		table.CharSet = db.charSet
 		tables = append(tables, table)
 	}
-	if err = rows.Err(); err != nil {
-		return
-	}
-	if tables, err = resolveTableConstraints(tables); err != nil {
-		return
-	}
-	if err = resolveTableRows(tables, db.queryTableRows); err != nil {
-		return
-	}
-	if err = db.applyFilters(tables); err != nil {
-		return
-	}
-	if db.server.args.Triggers {
-		for _, table := range tables {
-			if err = db.setTableTriggers(table); err != nil {
-				return
-			}
-		}
-	}
+	err = rows.Err()
 	return
 }
 
 // Views...
 func (db *MySQL5Database) Views() (views ViewGraph, err error) {
+	if !db.server.args.Views {
+		return
+	}
 	mysql5Stmts.Prepare(
 		"Views",
 		"SELECT `TABLE_NAME` "+
@@ -222,7 +361,26 @@ func (db *MySQL5Database) Views() (views ViewGraph, err error) {
 	if err = rows.Err(); err != nil {
 		return
 	}
-	return
+	setViewDependencies(views)
+	return resolveViewDependencies(views)
+}
+
+// setViewDependencies narrows the dependencies of each view, which hold every
+// table and view its definition selects from, down to the other views.
+func setViewDependencies(views ViewGraph) {
+	names := map[string]bool{}
+	for _, view := range views {
+		names[view.Name] = true
+	}
+	for _, view := range views {
+		deps := []string{}
+		for _, dep := range view.Dependencies {
+			if names[dep] && dep != view.Name && !stringsContain(deps, dep) {
+				deps = append(deps, dep)
+			}
+		}
+		view.Dependencies = deps
+	}
 }
 
 // Routines...
@@ -260,34 +418,85 @@ func (db *MySQL5Database) Routines() (routines RoutineGraph, err error) {
 	return
 }
 
+// checkPolicy...
+func (db *MySQL5Database) checkPolicy(tables TableGraph) (err error) {
+	policy := db.server.args.Policy
+	if policy == nil {
+		return
+	}
+	if err = policy.Drop(tables); err != nil {
+		return
+	}
+	violations := policy.Check(tables)
+	for _, v := range violations {
+		warning(v)
+	}
+	if len(violations) > 0 && !db.server.args.PolicyWarn {
+		err = fmt.Errorf("%d column(s) break the classification policy, refusing to dump.", len(violations))
+	}
+	return
+}
+
 // queryTableRows...
 func (db *MySQL5Database) queryTableRows(table *Table, fks map[string]mapset.Set) (rows Rows, err error) {
-	where := ""
-	if len(fks) > 0 {
-		wheres := []string{}
-		for col, set := range fks {
-			values := []string{}
-			for val := range set.Iter() {
-				values = append(values, val.(string))
+	binaryRows := db.binaryRows()
+	cols := []string{}
+	for col := range fks {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	wheres := []string{}
+	args := []interface{}{}
+	for _, col := range cols {
+		values := []string{}
+		for val := range fks[col].Iter() {
+			values = append(values, val.(string))
+		}
+		sort.Strings(values)
+		binary := binaryRows
+		if c, ok := table.Columns[col]; ok && mysql5BinaryTypes[c.DataType] {
+			binary = true
+		}
+		for _, val := range values {
+			if binary {
+				args = append(args, []byte(val))
+			} else {
+				args = append(args, val)
 			}
-			wheres = append(wheres, fmt.Sprintf("`%s` IN(%s)", col, MySQL5JoinValues(values)))
 		}
-		where = fmt.Sprintf("WHERE %s", strings.Join(wheres, " AND "))
+		wheres = append(wheres, fmt.Sprintf("%s IN(%s)", MySQL5Backtick(col), mysql5Placeholders(len(values))))
 	}
 
-	if err = db.lockTableRead(table.Name); err != nil {
+	// The lock and the select must use the same connection, and tables may be
+	// selected concurrently, so each table gets a connection of its own.
+	var conn *gosql.Conn
+	if conn, err = db.server.session(); err != nil {
+		return
+	}
+	defer conn.Close()
+	if binaryRows {
+		if err = db.setResultsCharSet(conn, "binary"); err != nil {
+			return
+		}
+		defer func() {
+			if err2 := db.setResultsCharSet(conn, mysql5ConnectionCharSet); err2 != nil && err == nil {
+				err = err2
+			}
+		}()
+	}
+	if err = db.lockTableRead(conn, table.Name); err != nil {
 		return
 	}
 	defer func() {
-		if err2 := db.unlockTables(); err2 != nil {
+		if err2 := db.unlockTables(conn); err2 != nil && err == nil {
 			err = err2
 		}
 	}()
 
-	sql := fmt.Sprintf("SELECT * FROM `%s` %s LIMIT %d", table.Name, where, db.server.args.Limit)
-	table.AppendDebugMsg(sql)
+	sql := db.selectRowsSQL(table, wheres)
+	table.AppendDebugMsg("%s", sql)
 	var qrows *gosql.Rows
-	if qrows, err = db.server.query(sql); err != nil {
+	if qrows, err = conn.QueryContext(context.Background(), sql, args...); err != nil {
 		warning(sql)
 		return
 	}
@@ -296,11 +505,62 @@ func (db *MySQL5Database) queryTableRows(table *Table, fks map[string]mapset.Set
 		return
 	}
 	if len(rows) == 0 {
-		warning("No rows found in `%s`.", table.Name)
+		warning("No rows found in %s.", MySQL5Backtick(table.Name))
 	}
 	return
 }
 
+// binaryRows returns whether the rows of the tables are read as binary. The
+// SQL formats read the value of each column in the character set of the
+// column, so tables which mix column character sets are dumped byte for byte
+// rather than converted to the connection character set, which would double
+// encode non-UTF-8 values when loaded. The other formats, and loading into a
+// database, read the rows in the connection character set.
+func (db *MySQL5Database) binaryRows() bool {
+	args := db.server.args
+	if args.Target != nil {
+		return false
+	}
+	switch args.Format {
+	case FormatCSV, FormatJSONL, FormatParquet:
+		return false
+	}
+	return true
+}
+
+// setResultsCharSet sets the character set of the results of the connection,
+// which must be restored before the connection is returned to the pool. The
+// statements and their arguments stay in the connection character set.
+func (db *MySQL5Database) setResultsCharSet(conn *gosql.Conn, charSet string) error {
+	_, err := conn.ExecContext(context.Background(), "SET character_set_results = "+MySQL5Quote(charSet))
+	return err
+}
+
+// selectRowsSQL returns the statement which selects the sampled rows of the
+// table, where each of the wheres is a condition the rows must match. Only the
+// columns of the table are selected, so columns dropped by the policy are never
+// read.
+func (db *MySQL5Database) selectRowsSQL(table *Table, wheres []string) string {
+	cols := "*"
+	if len(table.Columns) > 0 {
+		names := []string{}
+		for _, col := range table.Columns.Ordered() {
+			names = append(names, col.Name)
+		}
+		cols = MySQL5JoinColumns(names)
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = fmt.Sprintf(" WHERE %s", strings.Join(wheres, " AND "))
+	}
+	return fmt.Sprintf("SELECT %s FROM %s%s LIMIT %d", cols, MySQL5Backtick(table.Name), where, db.server.args.Limit)
+}
+
+// mysql5Placeholders returns n comma separated placeholders.
+func mysql5Placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
 // setTableConstraints...
 func (db *MySQL5Database) setTableConstraints(table *Table) (err error) {
 	mysql5Stmts.Prepare(
@@ -311,29 +571,80 @@ func (db *MySQL5Database) setTableConstraints(table *Table) (err error) {
 			"`COLUMN_NAME` "+
 			"FROM `INFORMATION_SCHEMA`.`KEY_COLUMN_USAGE` "+
 			"WHERE `REFERENCED_TABLE_SCHEMA` = ? "+
-			"AND `TABLE_NAME` = ? "+
-			"AND `REFERENCED_TABLE_NAME` != ?",
+			"AND `TABLE_NAME` = ?",
 	)
 	var rows *gosql.Rows
-	if rows, err = mysql5Stmts.Query("setTableConstraints", db.name, table.Name, table.Name); err != nil {
+	if rows, err = mysql5Stmts.Query("setTableConstraints", db.name, table.Name); err != nil {
 		return
 	}
 	defer rows.Close()
 
-	table.Constraints = []*Constraint{}
+	fks := []*Constraint{}
 	for rows.Next() {
 		fk := &Constraint{}
 		if err = rows.Scan(&fk.TableName, &fk.ColumnName, &fk.ReferencedColumnName); err != nil {
 			return
 		}
-		table.Constraints = append(table.Constraints, fk)
-		table.AppendDebugMsg("Constraint: %s -> %s.%s", fk.ReferencedColumnName, fk.TableName, fk.ColumnName)
+		fks = append(fks, fk)
+		table.AppendDebugMsg("Constraint: %s -> %s.%s", MySQL5Backtick(fk.ReferencedColumnName), MySQL5Backtick(fk.TableName), MySQL5Backtick(fk.ColumnName))
 	}
 	if err = rows.Err(); err != nil {
 		return
 	}
 	if _, ok := db.server.args.Constraints[table.Name]; ok {
-		table.Constraints = append(table.Constraints, db.server.args.Constraints[table.Name]...)
+		fks = append(fks, db.server.args.Constraints[table.Name]...)
+	}
+
+	// Rows referencing their own table cannot be sampled in dependency order,
+	// so self references are kept apart from the constraints.
+	table.Constraints = []*Constraint{}
+	table.SelfReferences = []*Constraint{}
+	for _, fk := range fks {
+		if fk.TableName == table.Name {
+			table.SelfReferences = append(table.SelfReferences, fk)
+		} else {
+			table.Constraints = append(table.Constraints, fk)
+		}
+	}
+	return
+}
+
+// setTableIndexes...
+func (db *MySQL5Database) setTableIndexes(table *Table) (err error) {
+	mysql5Stmts.Prepare(
+		"setTableIndexes",
+		"SELECT `INDEX_NAME`, `COLUMN_NAME` "+
+			"FROM `INFORMATION_SCHEMA`.`STATISTICS` "+
+			"WHERE `TABLE_SCHEMA` = ? "+
+			"AND `TABLE_NAME` = ? "+
+			"AND `NON_UNIQUE` = 0 "+
+			"ORDER BY `INDEX_NAME`, `SEQ_IN_INDEX`",
+	)
+	var rows *gosql.Rows
+	if rows, err = mysql5Stmts.Query("setTableIndexes", db.name, table.Name); err != nil {
+		return
+	}
+	defer rows.Close()
+
+	table.Indexes = []*Index{}
+	var index *Index
+	for rows.Next() {
+		var name, column string
+		if err = rows.Scan(&name, &column); err != nil {
+			return
+		}
+		if index == nil || index.Name != name {
+			index = &Index{
+				Name:    name,
+				Unique:  true,
+				Columns: []string{},
+			}
+			table.Indexes = append(table.Indexes, index)
+		}
+		index.Columns = append(index.Columns, column)
+	}
+	if err = rows.Err(); err != nil {
+		return
 	}
 	return
 }
@@ -373,7 +684,7 @@ func (db *MySQL5Database) setTableTriggers(table *Table) (err error) {
 // showCreateTable...
 func (db *MySQL5Database) setTableCreateSQL(table *Table) (err error) {
 	var rows *gosql.Rows
-	if rows, err = db.server.query("SHOW CREATE TABLE %s", MySQL5Backtick(table.Name)); err != nil {
+	if rows, err = db.server.query("SHOW CREATE TABLE " + MySQL5Backtick(table.Name)); err != nil {
 		return
 	}
 	defer rows.Close()
@@ -419,6 +730,9 @@ func (db *MySQL5Database) setViewCreateSQL(view *View) (err error) {
 	if err = rows.Scan(&view.CreateSQL, &view.Definer, &view.SecurityType, &view.CharSet, &view.Collation); err != nil {
 		return
 	}
+	if view.CreateSQL, view.Dependencies, err = mysql5UnqualifyView(view.CreateSQL, db.name); err != nil {
+		return
+	}
 	view.CreateSQL = fmt.Sprintf("VIEW %s AS %s", MySQL5Backtick(view.Name), view.CreateSQL)
 	view.Definer = MySQL5BacktickUser(view.Definer)
 	var cols ColumnMap
@@ -429,6 +743,51 @@ func (db *MySQL5Database) setViewCreateSQL(view *View) (err error) {
 	return
 }
 
+// mysql5UnqualifyView returns the view definition, as stored in
+// INFORMATION_SCHEMA.VIEWS, with the schema removed from the names it
+// qualifies, and the names of the tables and views the definition selects
+// from. The server qualifies every table with its schema but columns only with
+// their table, and string literals are copied untouched, so neither a literal
+// nor a column named after a view is taken for a reference.
+func mysql5UnqualifyView(def, schema string) (string, []string, error) {
+	b := &strings.Builder{}
+	names := []string{}
+	for i := 0; i < len(def); {
+		switch def[i] {
+		case '\'', '"':
+			end, err := mysql5QuotedEnd(def, i)
+			if err != nil {
+				return "", nil, err
+			}
+			b.WriteString(def[i:end])
+			i = end
+		case '`':
+			name, end, err := parseMySQL5Identifier(def, i)
+			if err != nil {
+				return "", nil, err
+			}
+			qualified := name == schema && (i == 0 || def[i-1] != '.') &&
+				end+1 < len(def) && def[end] == '.' && def[end+1] == '`'
+			if !qualified {
+				b.WriteString(def[i:end])
+				i = end
+				continue
+			}
+			next := 0
+			if name, next, err = parseMySQL5Identifier(def, end+1); err != nil {
+				return "", nil, err
+			}
+			names = append(names, name)
+			b.WriteString(def[end+1 : next])
+			i = next
+		default:
+			b.WriteByte(def[i])
+			i++
+		}
+	}
+	return b.String(), names, nil
+}
+
 // setRoutineCreateSQL...
 func (db *MySQL5Database) setRoutineCreateSQL(r *Routine) (err error) {
 	mysql5Stmts.Prepare(
@@ -515,7 +874,12 @@ func (db *MySQL5Database) tableColumns(tableName string) (cols ColumnMap, err er
 			"`ORDINAL_POSITION`, "+
 			"`COLUMN_TYPE`, "+
 			"`DATA_TYPE`, "+
-			"`CHARACTER_MAXIMUM_LENGTH` "+
+			"`CHARACTER_MAXIMUM_LENGTH`, "+
+			"`NUMERIC_PRECISION`, "+
+			"`NUMERIC_SCALE`, "+
+			"`IS_NULLABLE`, "+
+			"`CHARACTER_SET_NAME`, "+
+			"`COLLATION_NAME` "+
 			"FROM `INFORMATION_SCHEMA`.`COLUMNS` "+
 			"WHERE `TABLE_SCHEMA` = ? "+
 			"AND `TABLE_NAME` = ?",
@@ -530,15 +894,30 @@ func (db *MySQL5Database) tableColumns(tableName string) (cols ColumnMap, err er
 	for rows.Next() {
 		col := &Column{}
 		ml := gosql.NullInt64{}
+		np := gosql.NullInt64{}
+		ns := gosql.NullInt64{}
+		charSet := gosql.NullString{}
+		collation := gosql.NullString{}
+		var nullable string
 		if err = rows.Scan(
 			&col.Name,
 			&col.OrdinalPosition,
 			&col.Type,
 			&col.DataType,
-			&ml); err != nil {
+			&ml,
+			&np,
+			&ns,
+			&nullable,
+			&charSet,
+			&collation); err != nil {
 			return
 		}
+		col.IsNullable = nullable == "YES"
+		col.CharSet = charSet.String
+		col.Collation = collation.String
 		col.CharacterMaximumLength = ml.Int64
+		col.NumericPrecision = np.Int64
+		col.NumericScale = ns.Int64
 		cols[col.Name] = col
 	}
 	if err = rows.Err(); err != nil {
@@ -550,58 +929,195 @@ func (db *MySQL5Database) tableColumns(tableName string) (cols ColumnMap, err er
 // applyFilters...
 func (db *MySQL5Database) applyFilters(tables TableGraph) (err error) {
 	for _, table := range tables {
-		for _, row := range table.Rows {
-			for i, field := range row {
-				col := table.Columns[field.Column]
-				if err = Filters.Filter(
-					&row[i].Value,
-					table.Name,
-					field.Column,
-					col.DataType,
-					col.CharacterMaximumLength); err != nil {
-					return
+		var encodings map[string]encoding.Encoding
+		if encodings, err = db.filterEncodings(table); err != nil {
+			return
+		}
+		raws := make([]Row, len(table.Rows))
+		originals := make([]map[string]string, len(table.Rows))
+		for r, row := range table.Rows {
+			raws[r] = append(Row{}, row...)
+			originals[r] = make(map[string]string, len(row))
+			for _, field := range row {
+				originals[r][field.Column] = field.Value
+				if enc, ok := encodings[field.Column]; ok && !field.Null {
+					if originals[r][field.Column], err = enc.NewDecoder().String(field.Value); err != nil {
+						return
+					}
 				}
 			}
+			if err = db.filterRow(table, row, originals[r], nil); err != nil {
+				return
+			}
+		}
+		if err = db.uniqueFilteredIndexes(table, originals); err != nil {
+			return
+		}
+		if len(encodings) > 0 {
+			if err = db.encodeFilteredRows(table, raws, encodings); err != nil {
+				return
+			}
 		}
 	}
 	return
 }
 
-// buildSelectRowsSQL...
-func (db *MySQL5Database) buildSelectRowsSQL(tableName string, conditions map[string][]string) string {
-	where := db.buildWhereIn(conditions)
-	var limit string
-	if db.server.args.Limit != 0 {
-		limit = fmt.Sprintf("LIMIT %d", db.server.args.Limit)
+// filterEncodings returns the encodings of the columns of the table whose
+// values are read as binary in a character set other than UTF-8. The filters
+// work on UTF-8, so filtering a column in a character set which cannot be
+// decoded is an error.
+func (db *MySQL5Database) filterEncodings(table *Table) (map[string]encoding.Encoding, error) {
+	encodings := map[string]encoding.Encoding{}
+	if !db.binaryRows() {
+		return encodings, nil
 	}
-	return fmt.Sprintf(
-		"SELECT * FROM %s %s %s",
-		MySQL5Backtick(tableName),
-		where,
-		limit,
-	)
+	for _, col := range table.Columns {
+		if col.CharSet == "" || mysql5TextCharSets[col.CharSet] {
+			continue
+		}
+		if enc, ok := mysql5CharSetEncodings[col.CharSet]; ok {
+			encodings[col.Name] = enc
+		} else if Filters.HasFilter(table.Name, col.Name) {
+			return nil, fmt.Errorf(
+				"Column %s.%s cannot be filtered, the %s character set is not supported.",
+				MySQL5Backtick(table.Name),
+				MySQL5Backtick(col.Name),
+				col.CharSet,
+			)
+		}
+	}
+	return encodings, nil
+}
+
+// encodeFilteredRows encodes the filtered values of the decoded columns back to
+// the character set of their column, and restores the raw values of the columns
+// which are not filtered. Characters the character set cannot represent are
+// replaced with a question mark, as the server does when converting.
+func (db *MySQL5Database) encodeFilteredRows(table *Table, raws []Row, encodings map[string]encoding.Encoding) (err error) {
+	for r, row := range table.Rows {
+		for i, field := range row {
+			enc, ok := encodings[field.Column]
+			if !ok {
+				continue
+			}
+			if !Filters.HasFilter(table.Name, field.Column) {
+				row[i] = raws[r][i]
+				continue
+			}
+			if field.Null {
+				continue
+			}
+			if row[i].Value, err = encoding.ReplaceUnsupported(enc.NewEncoder()).String(field.Value); err != nil {
+				return
+			}
+		}
+	}
+	return
 }
 
-// buildWhereIn...
-func (db *MySQL5Database) buildWhereIn(conditions map[string][]string) string {
-	values := []string{}
-	for col, vals := range conditions {
-		if len(vals) > 0 {
-			cond := fmt.Sprintf("%s IN(%s)", MySQL5Backtick(col), MySQL5JoinValues(vals))
-			values = append(values, cond)
+// filterRow applies the filters to the row starting from the original values.
+// When columns is not nil only those columns are filtered.
+func (db *MySQL5Database) filterRow(table *Table, row Row, original map[string]string, columns []string) (err error) {
+	for i, field := range row {
+		if field.Null || columns != nil && !stringsContain(columns, field.Column) {
+			continue
+		}
+		col := table.Columns[field.Column]
+		row[i].Value = original[field.Column]
+		if err = Filters.Filter(&row[i].Value, &filters.Context{
+			TableName:  table.Name,
+			ColumnName: field.Column,
+			DataType:   col.DataType,
+			ColumnType: col.Type,
+			MaxLength:  col.CharacterMaximumLength,
+			Precision:  col.NumericPrecision,
+			Scale:      col.NumericScale,
+			Row:        original,
+		}); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// uniqueFilteredIndexes ensures filtered columns which are covered by a unique
+// index do not contain duplicate values. Duplicates are filtered again, which
+// helps random filters, and then string values are given a numeric suffix.
+func (db *MySQL5Database) uniqueFilteredIndexes(table *Table, originals []map[string]string) (err error) {
+	for _, index := range table.Indexes {
+		filtered := []string{}
+		for _, col := range index.Columns {
+			if Filters.HasFilter(table.Name, col) {
+				filtered = append(filtered, col)
+			}
+		}
+		if len(filtered) == 0 {
+			continue
+		}
+
+		seen := map[string]bool{}
+		for r, row := range table.Rows {
+			// Rows with a NULL in the index never collide.
+			if rowValuesEmpty(originals[r], index.Columns) || rowHasNull(row, index.Columns) {
+				continue
+			}
+			key := rowIndexKey(row, index.Columns, table.Columns)
+			for i := 0; seen[key] && i < mysql5UniqueRetries; i++ {
+				if err = db.filterRow(table, row, originals[r], filtered); err != nil {
+					return
+				}
+				key = rowIndexKey(row, index.Columns, table.Columns)
+			}
+			if seen[key] {
+				if key, err = db.suffixIndexValue(table, index, row, filtered, seen); err != nil {
+					return
+				}
+			}
+			seen[key] = true
 		}
 	}
-	if len(values) == 0 {
-		return ""
+	return
+}
+
+// suffixIndexValue appends a numeric suffix to a filtered string column in the
+// row until the index key is unique, and returns the new key.
+func (db *MySQL5Database) suffixIndexValue(table *Table, index *Index, row Row, filtered []string, seen map[string]bool) (string, error) {
+	for i, field := range row {
+		col := table.Columns[field.Column]
+		if !stringsContain(filtered, field.Column) || !mysql5StringTypes[col.DataType] {
+			continue
+		}
+		value := []rune(field.Value)
+		for n := 2; ; n++ {
+			suffix := fmt.Sprintf("_%d", n)
+			if col.CharacterMaximumLength > 0 && int64(len(suffix)) >= col.CharacterMaximumLength {
+				break
+			}
+			base := value
+			if max := int(col.CharacterMaximumLength) - len(suffix); col.CharacterMaximumLength > 0 && len(base) > max {
+				base = base[:max]
+			}
+			row[i].Value = string(base) + suffix
+			key := rowIndexKey(row, index.Columns, table.Columns)
+			if !seen[key] {
+				return key, nil
+			}
+		}
+		row[i].Value = field.Value
 	}
-	return fmt.Sprintf("WHERE %s", strings.Join(values, " AND "))
+	return "", fmt.Errorf(
+		"Filters on %s produce duplicate values for unique index %s (%s).",
+		MySQL5Backtick(table.Name),
+		MySQL5Backtick(index.Name),
+		MySQL5JoinColumns(append([]string{}, index.Columns...)),
+	)
 }
 
 // lockTableRead...
-func (db *MySQL5Database) lockTableRead(tableName string) error {
+func (db *MySQL5Database) lockTableRead(conn *gosql.Conn, tableName string) error {
 	if !db.server.args.SkipLockTables {
 		sql := fmt.Sprintf("LOCK TABLES %s READ LOCAL", MySQL5Backtick(tableName))
-		if err := db.server.exec(sql); err != nil {
+		if _, err := conn.ExecContext(context.Background(), sql); err != nil {
 			return err
 		}
 	}
@@ -609,9 +1125,9 @@ func (db *MySQL5Database) lockTableRead(tableName string) error {
 }
 
 // unlockTables...
-func (db *MySQL5Database) unlockTables() error {
+func (db *MySQL5Database) unlockTables(conn *gosql.Conn) error {
 	if !db.server.args.SkipLockTables {
-		if err := db.server.exec("UNLOCK TABLES"); err != nil {
+		if _, err := conn.ExecContext(context.Background(), "UNLOCK TABLES"); err != nil {
 			return err
 		}
 	}
@@ -620,9 +1136,13 @@ func (db *MySQL5Database) unlockTables() error {
 
 // MySQL5Escape...
 // @see https://dev.mysql.com/doc/refman/5.7/en/string-literals.html
+//
+// The value is escaped byte by byte, so binary values and strings in any
+// character set are passed through unchanged.
 func MySQL5Escape(val string) string {
 	b := bytes.Buffer{}
-	for _, c := range val {
+	for i := 0; i < len(val); i++ {
+		c := val[i]
 		switch c {
 		case '\000':
 			b.WriteString(`\0`)
@@ -641,21 +1161,35 @@ func MySQL5Escape(val string) string {
 		case '\\':
 			b.WriteString(`\\`)
 		default:
-			b.WriteRune(c)
+			b.WriteByte(c)
 		}
 	}
 	return b.String()
 }
 
-// MySQL5Backtick...
+// mysql5CommentReplacer escapes line breaks in comments.
+var mysql5CommentReplacer = strings.NewReplacer("\r", `\r`, "\n", `\n`)
+
+// MySQL5Backtick returns the identifier quoted with backticks, doubling the
+// backticks it contains.
 func MySQL5Backtick(col string) string {
-	return fmt.Sprintf("`%s`", col)
+	return "`" + strings.Replace(col, "`", "``", -1) + "`"
+}
+
+// MySQL5Comment returns the text with its line breaks escaped, so that it
+// cannot end the -- comment it is written in.
+func MySQL5Comment(s string) string {
+	return mysql5CommentReplacer.Replace(s)
 }
 
-// MySQL5BacktickUser...
+// MySQL5BacktickUser returns the quoted account of a definer. The host is the
+// part after the last @, as user names may contain @ but host names cannot.
 func MySQL5BacktickUser(user string) string {
-	parts := strings.SplitN(user, "@", 2)
-	return fmt.Sprintf("`%s`@`%s`", parts[0], parts[1])
+	i := strings.LastIndexByte(user, '@')
+	if i == -1 {
+		return MySQL5Backtick(user)
+	}
+	return MySQL5Backtick(user[:i]) + "@" + MySQL5Backtick(user[i+1:])
 }
 
 // MySQL5Quote...
@@ -663,6 +1197,27 @@ func MySQL5Quote(val string) string {
 	return fmt.Sprintf("'%s'", MySQL5Escape(val))
 }
 
+// MySQL5Hex returns the value as a hexadecimal literal.
+func MySQL5Hex(val string) string {
+	if val == "" {
+		return "''"
+	}
+	return "0x" + hex.EncodeToString([]byte(val))
+}
+
+// MySQL5Bit returns the raw value of a BIT column as a bit-value literal.
+func MySQL5Bit(val string) string {
+	b := bytes.Buffer{}
+	for i := 0; i < len(val); i++ {
+		fmt.Fprintf(&b, "%08b", val[i])
+	}
+	bits := strings.TrimLeft(b.String(), "0")
+	if bits == "" {
+		bits = "0"
+	}
+	return fmt.Sprintf("b'%s'", bits)
+}
+
 // MySQL5JoinValues...
 func MySQL5JoinValues(vals []string) string {
 	for i, val := range vals {
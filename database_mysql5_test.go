@@ -1,6 +1,10 @@
 package dbsample
 
-import "testing"
+import (
+	"github.com/headzoo/dbsample/filters"
+	"reflect"
+	"testing"
+)
 
 func TestMySQL5Escape(t *testing.T) {
 	tests := map[string]string{
@@ -38,7 +42,13 @@ func TestMySQL5Quote(t *testing.T) {
 
 func TestMySQL5Backtick(t *testing.T) {
 	tests := map[string]string{
-		`Hello world`: "`Hello world`",
+		`Hello world`:          "`Hello world`",
+		"Hello`world":          "`Hello``world`",
+		"`; DROP TABLE x; --":  "```; DROP TABLE x; --`",
+		"x'y\"z":               "`x'y\"z`",
+		"``":                   "``````",
+		"a\nb":                 "`a\nb`",
+		"Hello world` AND `1`": "`Hello world`` AND ``1```",
 	}
 	for s, ex := range tests {
 		ac := MySQL5Backtick(s)
@@ -48,6 +58,35 @@ func TestMySQL5Backtick(t *testing.T) {
 	}
 }
 
+func TestMySQL5BacktickUser(t *testing.T) {
+	tests := map[string]string{
+		"root@localhost":   "`root`@`localhost`",
+		"a@b@%":            "`a@b`@`%`",
+		"r`oot@local`host": "`r``oot`@`local``host`",
+		"root":             "`root`",
+	}
+	for s, ex := range tests {
+		ac := MySQL5BacktickUser(s)
+		if ex != ac {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestMySQL5Comment(t *testing.T) {
+	tests := map[string]string{
+		"`Hello world`":            "`Hello world`",
+		"`x`\nDROP TABLE `y`; -- ": "`x`\\nDROP TABLE `y`; -- ",
+		"a\r\nb":                   `a\r\nb`,
+	}
+	for s, ex := range tests {
+		ac := MySQL5Comment(s)
+		if ex != ac {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
 func TestMySQL5JoinValues(t *testing.T) {
 	tests := map[string][]string{
 		`'Hello world'`:            []string{`Hello world`},
@@ -73,3 +112,256 @@ func TestMySQL5JoinColumns(t *testing.T) {
 		}
 	}
 }
+
+func TestMySQL5UniqueFilteredIndexes(t *testing.T) {
+	defer func(f *filters.FilterController) {
+		Filters = f
+	}(Filters)
+	Filters = filters.NewFilterController()
+	if err := Filters.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Filters.SetCommands([]string{"repeat users.email X"}); err != nil {
+		t.Fatal(err)
+	}
+	table := NewTable()
+	table.Name = "users"
+	table.Columns = ColumnMap{
+		"id":    &Column{Name: "id", DataType: "int"},
+		"email": &Column{Name: "email", DataType: "varchar", CharacterMaximumLength: 5},
+		"org":   &Column{Name: "org", DataType: "int"},
+	}
+	table.Indexes = []*Index{
+		&Index{Name: "email", Unique: true, Columns: []string{"email"}},
+		&Index{Name: "org_email", Unique: true, Columns: []string{"org", "email"}},
+	}
+	table.Rows = Rows{
+		Row{Field{Column: "id", Value: "1"}, Field{Column: "email", Value: "a@example.com"}, Field{Column: "org", Null: true}},
+		Row{Field{Column: "id", Value: "2"}, Field{Column: "email", Value: "b@example.com"}, Field{Column: "org", Null: true}},
+		Row{Field{Column: "id", Value: "3"}, Field{Column: "email", Value: "c@example.com"}, Field{Column: "org", Null: true}},
+		Row{Field{Column: "id", Value: "4"}, Field{Column: "email", Null: true}, Field{Column: "org", Value: "1"}},
+		Row{Field{Column: "id", Value: "5"}, Field{Column: "email", Null: true}, Field{Column: "org", Value: "1"}},
+	}
+	db := &MySQL5Database{server: &Server{args: &DumpArgs{Format: FormatSQL}}}
+	if err := db.applyFilters(TableGraph{table}); err != nil {
+		t.Fatal(err)
+	}
+	for i, ex := range []string{"XXXXX", "XXX_2", "XXX_3", "", ""} {
+		if ac := table.Rows[i][1].Value; ac != ex {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+
+	table.Columns["email"].DataType = "int"
+	if err := db.applyFilters(TableGraph{table}); err == nil {
+		t.Error("Expected an error naming the unique index")
+	}
+}
+
+func TestMySQL5ApplyFiltersCharSet(t *testing.T) {
+	defer func(f *filters.FilterController) {
+		Filters = f
+	}(Filters)
+	Filters = filters.NewFilterController()
+	if err := Filters.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Filters.SetCommands([]string{"mask users.name 1 é", "repeat users.code é"}); err != nil {
+		t.Fatal(err)
+	}
+	newTable := func() *Table {
+		table := NewTable()
+		table.Name = "users"
+		table.Columns = ColumnMap{
+			"name":  &Column{Name: "name", DataType: "varchar", CharSet: "latin1", CharacterMaximumLength: 4},
+			"code":  &Column{Name: "code", DataType: "varchar", CharSet: "latin1", CharacterMaximumLength: 3},
+			"title": &Column{Name: "title", DataType: "varchar", CharSet: "latin1"},
+		}
+		table.Indexes = []*Index{
+			&Index{Name: "code", Unique: true, Columns: []string{"code"}},
+		}
+		table.Rows = Rows{
+			Row{Field{Column: "name", Value: "caf\xe9"}, Field{Column: "code", Value: "a"}, Field{Column: "title", Value: "\x81\xe9"}},
+			Row{Field{Column: "name", Value: "\xe9t\xe9"}, Field{Column: "code", Value: "b"}, Field{Column: "title", Null: true}},
+		}
+		return table
+	}
+
+	table := newTable()
+	db := &MySQL5Database{server: &Server{args: &DumpArgs{Format: FormatSQL}}}
+	if err := db.applyFilters(TableGraph{table}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		row, field int
+		ex         string
+	}{
+		{0, 0, "\xe9\xe9\xe9\xe9"},
+		{0, 1, "\xe9\xe9\xe9"},
+		{0, 2, "\x81\xe9"},
+		{1, 0, "\xe9\xe9\xe9"},
+		{1, 1, "\xe9_2"},
+	}
+	for _, test := range tests {
+		if ac := table.Rows[test.row][test.field].Value; ac != test.ex {
+			t.Errorf(`Expected '%q', got '%q'`, test.ex, ac)
+		}
+	}
+
+	table = newTable()
+	db.server.args.Format = FormatCSV
+	if err := db.applyFilters(TableGraph{table}); err != nil {
+		t.Fatal(err)
+	}
+	if ac := table.Rows[0][1].Value; ac != "ééé" {
+		t.Errorf(`Expected '%s', got '%s'`, "ééé", ac)
+	}
+
+	table = newTable()
+	table.Columns["name"].CharSet = "swe7"
+	db.server.args.Format = FormatSQL
+	if err := db.applyFilters(TableGraph{table}); err == nil {
+		t.Error("Expected an unsupported character set error")
+	}
+}
+
+func TestRowIndexKey(t *testing.T) {
+	cols := ColumnMap{
+		"id":    &Column{Name: "id", DataType: "int"},
+		"email": &Column{Name: "email", DataType: "varchar", Collation: "utf8mb4_general_ci"},
+		"code":  &Column{Name: "code", DataType: "varchar", Collation: "utf8mb4_bin"},
+	}
+	row := func(email, code string) Row {
+		return Row{Field{Column: "id", Value: "1"}, Field{Column: "email", Value: email}, Field{Column: "code", Value: code}}
+	}
+	columns := []string{"email", "code"}
+	a := rowIndexKey(row("A@x.com", "x"), columns, cols)
+	if b := rowIndexKey(row("a@x.com ", "x "), columns, cols); a != b {
+		t.Errorf(`Expected '%q', got '%q'`, a, b)
+	}
+	if b := rowIndexKey(row("a@x.com", "X"), columns, cols); a == b {
+		t.Errorf(`Expected a key other than '%q'`, a)
+	}
+}
+
+func TestMySQL5EscapeBinary(t *testing.T) {
+	tests := map[string]string{
+		"\xff\xfe'\x00": "\xff\xfe\\'\\0",
+		"caf\xe9":       "caf\xe9",
+		"Hello é":       "Hello é",
+	}
+	for s, ex := range tests {
+		ac := MySQL5Escape(s)
+		if ex != ac {
+			t.Errorf(`Expected '%q', got '%q'`, ex, ac)
+		}
+	}
+}
+
+func TestMySQL5Hex(t *testing.T) {
+	tests := map[string]string{
+		"":                 `''`,
+		"\x00\xff\x10":     `0x00ff10`,
+		"\x8f\xe2\x9a\x01": `0x8fe29a01`,
+	}
+	for s, ex := range tests {
+		ac := MySQL5Hex(s)
+		if ex != ac {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestMySQL5Bit(t *testing.T) {
+	tests := map[string]string{
+		"\x00":     `b'0'`,
+		"\x01":     `b'1'`,
+		"\x05":     `b'101'`,
+		"\x01\x00": `b'100000000'`,
+	}
+	for s, ex := range tests {
+		ac := MySQL5Bit(s)
+		if ex != ac {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestMySQL5SelectRowsSQL(t *testing.T) {
+	db := &MySQL5Database{server: &Server{args: &DumpArgs{Limit: 10}}}
+	table := NewTable()
+	table.Name = "users"
+	tests := []struct {
+		columns ColumnMap
+		wheres  []string
+		ex      string
+	}{
+		{ColumnMap{}, nil, "SELECT * FROM `users` LIMIT 10"},
+		{ColumnMap{}, []string{"`a` IN(1)", "`b` IN(2)"}, "SELECT * FROM `users` WHERE `a` IN(1) AND `b` IN(2) LIMIT 10"},
+		{
+			ColumnMap{
+				"name": &Column{Name: "name", OrdinalPosition: 2},
+				"id":   &Column{Name: "id", OrdinalPosition: 1},
+			},
+			nil,
+			"SELECT `id`, `name` FROM `users` LIMIT 10",
+		},
+	}
+	for _, test := range tests {
+		table.Columns = test.columns
+		ac := db.selectRowsSQL(table, test.wheres)
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
+
+func TestMySQL5BinaryRows(t *testing.T) {
+	tests := []struct {
+		args *DumpArgs
+		ex   bool
+	}{
+		{&DumpArgs{Format: FormatSQL}, true},
+		{&DumpArgs{Format: FormatDir}, true},
+		{&DumpArgs{Format: FormatTab}, true},
+		{&DumpArgs{Format: FormatCSV}, false},
+		{&DumpArgs{Format: FormatJSONL}, false},
+		{&DumpArgs{Format: FormatParquet}, false},
+		{&DumpArgs{Format: FormatSQL, Target: &ConnectionArgs{}}, false},
+	}
+	for _, test := range tests {
+		db := &MySQL5Database{server: &Server{args: test.args}}
+		if ac := db.binaryRows(); ac != test.ex {
+			t.Errorf(`Expected '%v' for %s, got '%v'`, test.ex, test.args.Format, ac)
+		}
+	}
+}
+
+func TestMySQL5UnqualifyView(t *testing.T) {
+	def := "select `blog`.`users`.`report` AS `r`,`u`.`active_users` AS `active_users`," +
+		"'`blog`.`report`' AS `s`,_utf8mb4'it\\'s `blog`.`x`' AS `t` " +
+		"from (`blog`.`users` `u` join `blog`.`active_users` `a`) where `a`.`id` = `u`.`id`"
+	ex := "select `users`.`report` AS `r`,`u`.`active_users` AS `active_users`," +
+		"'`blog`.`report`' AS `s`,_utf8mb4'it\\'s `blog`.`x`' AS `t` " +
+		"from (`users` `u` join `active_users` `a`) where `a`.`id` = `u`.`id`"
+	ac, names, err := mysql5UnqualifyView(def, "blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+
+	views := ViewGraph{
+		&View{Name: "report", Dependencies: names},
+		&View{Name: "active_users", Dependencies: []string{"users"}},
+		&View{Name: "users_view", Dependencies: []string{"users"}},
+	}
+	setViewDependencies(views)
+	exDeps := [][]string{{"active_users"}, {}, {}}
+	for i, view := range views {
+		if !reflect.DeepEqual(view.Dependencies, exDeps[i]) {
+			t.Errorf(`Expected '%v', got '%v'`, exDeps[i], view.Dependencies)
+		}
+	}
+}
@@ -43,6 +43,9 @@ func Dump() error {
 	if err != nil {
 		return err
 	}
+	if args.DumpFile != "" {
+		return VerifyDump(os.Stdout, args.DumpFile)
+	}
 	server := NewServer(conn, args)
 	if err := server.Open(); err != nil {
 		return err
@@ -54,9 +57,43 @@ func Dump() error {
 	}
 	//db.Tables()
 	//return nil
+	if args.Plan {
+		return db.Plan(os.Stdout)
+	}
+	if args.Graph {
+		return db.Graph(os.Stdout, args.GraphFormat)
+	}
+	if args.Target != nil {
+		return load(args.Target, args, db)
+	}
 	dumper, err := NewDumper(server)
 	if err != nil {
 		return err
 	}
-	return dumper.Dump(os.Stdout, db)
+	if args.Format != FormatSQL {
+		return dumper.Dump(os.Stdout, db)
+	}
+	w, err := NewResultWriter(args.ResultFile, args.Compress)
+	if err != nil {
+		return err
+	}
+	if err := dumper.Dump(w, db); err != nil {
+		w.Abort()
+		return err
+	}
+	return w.Close()
+}
+
+// load...
+func load(conn *ConnectionArgs, args *DumpArgs, db Database) error {
+	target := NewServer(conn, args)
+	if err := target.Open(); err != nil {
+		return err
+	}
+	defer target.Close()
+	loader, err := NewLoader(target)
+	if err != nil {
+		return err
+	}
+	return loader.Load(os.Stderr, db)
 }
@@ -5,6 +5,15 @@ import (
 	"io"
 )
 
+const (
+	FormatSQL     = "sql"
+	FormatDir     = "dir"
+	FormatTab     = "tab"
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+)
+
 // Dumper...
 type Dumper interface {
 	Dump(w io.Writer, db Database) error
@@ -12,11 +21,28 @@ type Dumper interface {
 
 // NewDumper returns a Dumper instance.
 func NewDumper(s *Server) (Dumper, error) {
+	switch s.args.Format {
+	case FormatCSV:
+		return NewFileDumper(s.args, NewCSVEncoder(s.args.CSVNull)), nil
+	case FormatJSONL:
+		return NewFileDumper(s.args, NewJSONLEncoder()), nil
+	case FormatParquet:
+		return NewFileDumper(s.args, NewParquetEncoder()), nil
+	}
+
 	switch s.conn.Driver {
 	case DriverMySQL:
 		switch s.major {
 		case "5":
-			return NewMySQL5Dumper(s.args), nil
+			switch s.args.Format {
+			case FormatSQL:
+				return NewMySQL5Dumper(s.args), nil
+			case FormatDir:
+				return NewMySQL5DirDumper(s.args), nil
+			case FormatTab:
+				return NewMySQL5TabDumper(s.args), nil
+			}
+			return nil, fmt.Errorf("Dumper not available for format %s", s.args.Format)
 		}
 	}
 	return nil, fmt.Errorf("Dumper not available for %s %s", s.conn.Driver, s.major)
@@ -0,0 +1,197 @@
+package dbsample
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TableEncoder writes the rows of a table to a data file.
+type TableEncoder interface {
+	// Extension returns the extension of the data files, e.g. ".csv".
+	Extension() string
+	// Columns returns the columns in the order they are written to the file.
+	Columns(cols []*Column) []*Column
+	// Type returns the type the values of the column are written as.
+	Type(col *Column) string
+	// Encode writes the rows, where the fields of each row are in the same
+	// order as the columns.
+	Encode(w io.Writer, cols []*Column, rows Rows) error
+}
+
+// FileSchema is written to schema.json in the output directory, and describes
+// the data files.
+type FileSchema struct {
+	Database   string             `json:"database"`
+	Format     string             `json:"format"`
+	DumpDate   string             `json:"dump_date"`
+	AppVersion string             `json:"app_version"`
+	Tables     []*FileSchemaTable `json:"tables"`
+}
+
+// FileSchemaTable...
+type FileSchemaTable struct {
+	Name        string                  `json:"name"`
+	File        string                  `json:"file"`
+	Rows        int                     `json:"rows"`
+	Columns     []*FileSchemaColumn     `json:"columns"`
+	ForeignKeys []*FileSchemaForeignKey `json:"foreign_keys"`
+}
+
+// FileSchemaColumn...
+type FileSchemaColumn struct {
+	Name       string `json:"name"`
+	DataType   string `json:"data_type"`
+	ColumnType string `json:"column_type"`
+	Nullable   bool   `json:"nullable"`
+	Type       string `json:"type"`
+}
+
+// FileSchemaForeignKey...
+type FileSchemaForeignKey struct {
+	Column           string `json:"column"`
+	ReferencedTable  string `json:"referenced_table"`
+	ReferencedColumn string `json:"referenced_column"`
+}
+
+// FileDumper writes the rows of each table to a data file in the output
+// directory, along with a schema.json file describing the tables. Views,
+// routines and triggers are not written.
+//
+// The writer passed to Dump is not used.
+type FileDumper struct {
+	args    *DumpArgs
+	dir     string
+	encoder TableEncoder
+}
+
+// NewFileDumper returns a new *FileDumper instance.
+func NewFileDumper(args *DumpArgs, encoder TableEncoder) *FileDumper {
+	return &FileDumper{
+		args:    args,
+		dir:     args.OutputDir,
+		encoder: encoder,
+	}
+}
+
+// Dump...
+func (g *FileDumper) Dump(w io.Writer, db Database) error {
+	tables, err := db.Tables()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(g.dir, 0755); err != nil {
+		return err
+	}
+
+	schema := &FileSchema{
+		Database:   db.Name(),
+		Format:     g.args.Format,
+		DumpDate:   time.Now().Format("2006-01-02 15:04:05"),
+		AppVersion: Version,
+		Tables:     []*FileSchemaTable{},
+	}
+	if g.args.RenameDatabase != "" {
+		schema.Database = g.args.RenameDatabase
+	}
+	for _, table := range tables {
+		cols := g.encoder.Columns(table.Columns.Ordered())
+		st := &FileSchemaTable{
+			Name:        table.Name,
+			File:        safeFileName(table.Name) + g.encoder.Extension() + compressExtension(g.args.Compress),
+			Rows:        len(table.Rows),
+			Columns:     []*FileSchemaColumn{},
+			ForeignKeys: []*FileSchemaForeignKey{},
+		}
+		for _, col := range cols {
+			st.Columns = append(st.Columns, &FileSchemaColumn{
+				Name:       col.Name,
+				DataType:   col.DataType,
+				ColumnType: col.Type,
+				Nullable:   col.IsNullable,
+				Type:       g.encoder.Type(col),
+			})
+		}
+		for _, fk := range table.Constraints {
+			st.ForeignKeys = append(st.ForeignKeys, &FileSchemaForeignKey{
+				Column:           fk.ReferencedColumnName,
+				ReferencedTable:  fk.TableName,
+				ReferencedColumn: fk.ColumnName,
+			})
+		}
+
+		fw, err := NewResultWriter(filepath.Join(g.dir, st.File), g.args.Compress)
+		if err != nil {
+			return err
+		}
+		if err = g.encoder.Encode(fw, cols, fileOrderedRows(table.Rows, cols)); err != nil {
+			fw.Abort()
+			return fmt.Errorf("Writing %s failed: %s", MySQL5Backtick(table.Name), err)
+		}
+		if err = fw.Close(); err != nil {
+			return err
+		}
+		schema.Tables = append(schema.Tables, st)
+	}
+	return g.writeSchema(schema)
+}
+
+// writeSchema...
+func (g *FileDumper) writeSchema(schema *FileSchema) error {
+	fw, err := NewResultWriter(filepath.Join(g.dir, "schema.json"), CompressNone)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(schema); err != nil {
+		fw.Abort()
+		return err
+	}
+	return fw.Close()
+}
+
+// fileOrderedRows returns the rows with their fields in the same order as the
+// columns. Missing fields are NULL.
+func fileOrderedRows(rows Rows, cols []*Column) Rows {
+	ordered := make(Rows, len(rows))
+	for i, row := range rows {
+		fields := make(map[string]Field, len(row))
+		for _, field := range row {
+			fields[field.Column] = field
+		}
+		ordered[i] = make(Row, len(cols))
+		for j, col := range cols {
+			field, ok := fields[col.Name]
+			if !ok {
+				field = Field{Column: col.Name, Null: true}
+			}
+			ordered[i][j] = field
+		}
+	}
+	return ordered
+}
+
+// fileBinaryType returns whether values of the data type are bytes rather
+// than text.
+func fileBinaryType(dataType string) bool {
+	switch dataType {
+	case "geometry", "point", "linestring", "polygon", "multipoint",
+		"multilinestring", "multipolygon", "geometrycollection", "geomcollection":
+		return true
+	}
+	return mysql5BinaryTypes[dataType]
+}
+
+// fileBitValue returns the value of a bit column, which is stored as big-endian
+// bytes, as an integer.
+func fileBitValue(val string) uint64 {
+	var n uint64
+	for i := 0; i < len(val); i++ {
+		n = n<<8 | uint64(val[i])
+	}
+	return n
+}
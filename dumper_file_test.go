@@ -0,0 +1,121 @@
+package dbsample
+
+import (
+	"bytes"
+	pq "github.com/parquet-go/parquet-go"
+	"testing"
+)
+
+var fileTestColumns = []*Column{
+	{Name: "id", DataType: "int"},
+	{Name: "name", DataType: "varchar"},
+	{Name: "data", DataType: "blob"},
+	{Name: "doc", DataType: "json"},
+}
+
+var fileTestRows = Rows{
+	Row{{Column: "id", Value: "1"}, {Column: "name", Value: `a,"b"`}, {Column: "data", Value: "\x00\xff"}, {Column: "doc", Value: `{"a": 1}`}},
+	Row{{Column: "id", Value: "x"}, {Column: "name", Null: true}, {Column: "data", Null: true}, {Column: "doc", Value: "{"}},
+	Row{{Column: "id", Value: "3"}, {Column: "name", Value: `\N`}, {Column: "data", Value: ""}, {Column: "doc", Null: true}},
+}
+
+func TestCSVEncoder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewCSVEncoder(`\N`).Encode(buf, fileTestColumns, fileTestRows); err != nil {
+		t.Fatal(err)
+	}
+	ex := "id,name,data,doc\r\n" +
+		"1,\"a,\"\"b\"\"\",00ff,\"{\"\"a\"\": 1}\"\r\n" +
+		"x,\\N,\\N,{\r\n" +
+		"3,\"\\N\",,\\N\r\n"
+	if ac := buf.String(); ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+}
+
+func TestJSONLEncoder(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewJSONLEncoder().Encode(buf, fileTestColumns, fileTestRows); err != nil {
+		t.Fatal(err)
+	}
+	ex := `{"id":1,"name":"a,\"b\"","data":"AP8=","doc":{"a":1}}` + "\n" +
+		`{"id":"x","name":null,"data":null,"doc":"{"}` + "\n" +
+		`{"id":3,"name":"\\N","data":"","doc":null}` + "\n"
+	if ac := buf.String(); ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+}
+
+func TestParquetDecimal(t *testing.T) {
+	tests := map[string]int64{
+		"12.5":   1250,
+		"-12.50": -1250,
+		"0.01":   1,
+		"3":      300,
+		"-0.5":   -50,
+	}
+	for val, ex := range tests {
+		ac, err := parquetDecimal(val, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ac != ex {
+			t.Errorf(`Expected '%d', got '%d'`, ex, ac)
+		}
+	}
+	if _, err := parquetDecimal("abc", 2); err == nil {
+		t.Error("Expected an invalid decimal error")
+	}
+}
+
+func TestParquetEncoder(t *testing.T) {
+	enc := NewParquetEncoder()
+	cols := []*Column{
+		{Name: "name", DataType: "varchar"},
+		{Name: "id", DataType: "bigint", Type: "bigint(20) unsigned"},
+		{Name: "amount", DataType: "decimal", NumericPrecision: 10, NumericScale: 2},
+	}
+	rows := Rows{
+		Row{{Column: "name", Value: "a"}, {Column: "id", Value: "1"}, {Column: "amount", Value: "10.5"}},
+		Row{{Column: "name", Null: true}, {Column: "id", Value: "2"}, {Column: "amount", Null: true}},
+	}
+	ordered := enc.Columns(cols)
+	buf := &bytes.Buffer{}
+	if err := enc.Encode(buf, ordered, fileOrderedRows(rows, ordered)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := pq.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		typ  string
+	}{
+		{"amount", "INT64 (DECIMAL(10,2))"},
+		{"id", "INT64 (INT(64,false))"},
+		{"name", "BYTE_ARRAY (STRING)"},
+	}
+	fields := f.Schema().Fields()
+	for i, test := range tests {
+		if ordered[i].Name != test.name || fields[i].Name() != test.name {
+			t.Errorf(`Expected '%s', got '%s' and '%s'`, test.name, ordered[i].Name, fields[i].Name())
+		}
+		if ac := enc.Type(ordered[i]); ac != test.typ {
+			t.Errorf(`Expected '%s', got '%s'`, test.typ, ac)
+		}
+	}
+
+	read := make([]pq.Row, 2)
+	n, _ := pq.NewReader(bytes.NewReader(buf.Bytes())).ReadRows(read)
+	if n != 2 {
+		t.Fatalf(`Expected '%d', got '%d'`, 2, n)
+	}
+	if read[0][0].Int64() != 1050 || read[0][1].Int64() != 1 || string(read[0][2].ByteArray()) != "a" {
+		t.Errorf(`Unexpected row %v`, read[0])
+	}
+	if !read[1][0].IsNull() || read[1][1].Int64() != 2 || !read[1][2].IsNull() {
+		t.Errorf(`Unexpected row %v`, read[1])
+	}
+}
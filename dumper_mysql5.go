@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/headzoo/dbsample/templates"
+	"golang.org/x/text/encoding"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -15,12 +18,15 @@ import (
 
 const MySQL5DumperTemplatesPath = "./templates/mysql"
 
-var dbname = regexp.MustCompile("CREATE DATABASE `[^`]+`")
+var dbname = regexp.MustCompile("CREATE DATABASE `(?:[^`]|``)+`")
 
 // MySQL5DumperTemplateValues...
 type MySQL5DumperTemplateValues struct {
 	ShouldDumpDatabase   bool
 	ShouldDumpTables     bool
+	ShouldDumpSchema     bool
+	ShouldDumpData       bool
+	ShouldLoadData       bool
 	ShouldDumpViews      bool
 	ShouldDumpRoutines   bool
 	ShouldDumpTriggers   bool
@@ -58,41 +64,53 @@ func NewMySQL5Dumper(args *DumpArgs) *MySQL5Dumper {
 
 // Dump...
 func (g *MySQL5Dumper) Dump(w io.Writer, db Database) error {
+	vals, err := g.templateValues(db)
+	if err != nil {
+		return err
+	}
+	return g.execute(w, vals)
+}
+
+// templateValues reads the database objects and returns the values passed to
+// the templates.
+func (g *MySQL5Dumper) templateValues(db Database) (vals MySQL5DumperTemplateValues, err error) {
 	start := time.Now()
 	tables, err := db.Tables()
 	if err != nil {
-		return err
+		return
 	}
 	views, err := db.Views()
 	if err != nil {
-		return err
+		return
 	}
 	routines, err := db.Routines()
 	if err != nil {
-		return err
+		return
 	}
 
 	origDatabaseName := db.Name()
 	if g.args.RenameDatabase != "" {
-		sql, err := db.CreateSQL()
-		if err != nil {
-			return err
+		var sql string
+		if sql, err = db.CreateSQL(); err != nil {
+			return
 		}
-		sql = dbname.ReplaceAllString(
+		sql = dbname.ReplaceAllLiteralString(
 			sql,
-			fmt.Sprintf("CREATE DATABASE `%s`", g.args.RenameDatabase),
+			"CREATE DATABASE "+MySQL5Backtick(g.args.RenameDatabase),
 		)
 		db.SetCreateSQL(sql)
 		db.SetName(g.args.RenameDatabase)
 	}
 
-	if err := g.parseTemplates(); err != nil {
-		return err
+	if err = g.parseTemplates(); err != nil {
+		return
 	}
-	vals := MySQL5DumperTemplateValues{
+	vals = MySQL5DumperTemplateValues{
 		ShouldDumpDatabase:   !g.args.NoCreateDatabase,
 		ShouldDumpTables:     true,
-		ShouldDumpViews:      false,
+		ShouldDumpSchema:     true,
+		ShouldDumpData:       true,
+		ShouldDumpViews:      g.args.Views,
 		ShouldDumpRoutines:   g.args.Routines,
 		ShouldDumpTriggers:   g.args.Triggers,
 		Debug:                IsDebugging,
@@ -101,8 +119,8 @@ func (g *MySQL5Dumper) Dump(w io.Writer, db Database) error {
 		Database:             db,
 		OriginalDatabaseName: origDatabaseName,
 		Args:                 g.args,
-		CharSet:              db.CharSet(),
-		Collation:            db.Collation(),
+		CharSet:              mysql5ConnectionCharSet,
+		Collation:            mysql5ConnectionCollation,
 		DumpDate:             time.Now().Format("2006-01-02 15:04:05"),
 		DumpDuration:         fmt.Sprintf("%s", time.Since(start)),
 		Connection:           db.Server().conn,
@@ -111,16 +129,23 @@ func (g *MySQL5Dumper) Dump(w io.Writer, db Database) error {
 		Views:                views,
 		Routines:             routines,
 	}
-	if err := g.templates.ExecuteTemplate(w, "templates/mysql/dump.sql.tmpl", vals); err != nil {
-		return err
-	}
-	return nil
+	return
+}
+
+// execute...
+func (g *MySQL5Dumper) execute(w io.Writer, vals MySQL5DumperTemplateValues) error {
+	return g.templates.ExecuteTemplate(w, "templates/mysql/dump.sql.tmpl", vals)
 }
 
 // parseTemplates...
 func (g *MySQL5Dumper) parseTemplates() error {
 	g.templates.Funcs(template.FuncMap{
-		"TableInserts": g.tableInserts,
+		"TableInserts":   g.tableInserts,
+		"TableLoadData":  g.tableLoadData,
+		"TableChecksum":  g.tableChecksum,
+		"TableCreateSQL": g.tableCreateSQL,
+		"Backtick":       MySQL5Backtick,
+		"Comment":        MySQL5Comment,
 	})
 
 	var err error
@@ -157,11 +182,11 @@ func (g *MySQL5Dumper) tableInserts(table *Table) string {
 		return ""
 	}
 
-	types := []string{}
+	types := []*Column{}
 	cols := []string{}
 	for _, row := range table.Rows[0] {
 		cols = append(cols, row.Column)
-		types = append(types, table.Columns[row.Column].DataType)
+		types = append(types, table.Columns[row.Column])
 	}
 	columns := MySQL5JoinColumns(cols)
 	sep := ""
@@ -171,43 +196,102 @@ func (g *MySQL5Dumper) tableInserts(table *Table) string {
 	if g.args.ExtendedInsert {
 		inserts := []string{}
 		for _, row := range table.Rows {
-			vals := []string{}
-			for _, v := range row {
-				vals = append(vals, v.Value)
-			}
 			inserts = append(inserts, fmt.Sprintf(
-				"INSERT INTO `%s` (%s)%sVALUES(%s);",
-				table.Name,
+				"INSERT INTO %s (%s)%sVALUES(%s);",
+				MySQL5Backtick(table.Name),
 				columns,
 				sep,
-				g.joinValues(vals, types),
+				g.joinValues(row, types),
 			))
 		}
 		return strings.Join(inserts, "\n")
 	} else {
 		values := []string{}
 		for _, row := range table.Rows {
-			vals := []string{}
-			for _, v := range row {
-				vals = append(vals, v.Value)
-			}
-			values = append(values, fmt.Sprintf("(%s)", g.joinValues(vals, types)))
+			values = append(values, fmt.Sprintf("(%s)", g.joinValues(row, types)))
 		}
-		return fmt.Sprintf("INSERT INTO `%s` (%s)%sVALUES %s;\n", table.Name, columns, sep, strings.Join(values, ","))
+		return fmt.Sprintf("INSERT INTO %s (%s)%sVALUES %s;\n", MySQL5Backtick(table.Name), columns, sep, strings.Join(values, ","))
 	}
 }
 
-// joinValues...
-func (g *MySQL5Dumper) joinValues(vals []string, types []string) string {
-	for i, val := range vals {
-		if strings.Contains(types[i], "int") {
-			if val == "" {
-				val = "0"
-			}
-			vals[i] = val
+// tableChecksum returns the number of rows and the checksum of their values,
+// which "dbsample verify" compares with the rows restored from the dump. Like
+// CHECKSUM TABLE the checksum is the sum of the checksum of each row, so it
+// does not depend on the order the rows are read back in.
+func (g *MySQL5Dumper) tableChecksum(table *Table) string {
+	var sum uint32
+	for _, row := range table.Rows {
+		sum += mysql5RowChecksum(row)
+	}
+	return fmt.Sprintf("Rows: %d, Checksum: %08x", len(table.Rows), sum)
+}
+
+// tableCreateSQL returns the CREATE TABLE statement of the table in the
+// character set of the table, which is the client character set while the
+// table is created. Statements are read in the connection character set, and
+// are written as read when the table is in a text character set, or one which
+// cannot be encoded.
+func (g *MySQL5Dumper) tableCreateSQL(table *Table) (string, error) {
+	enc, ok := mysql5CharSetEncodings[table.CharSet]
+	if !ok {
+		return table.CreateSQL, nil
+	}
+	return encoding.ReplaceUnsupported(enc.NewEncoder()).String(table.CreateSQL)
+}
+
+// mysql5RowChecksum returns the CRC32 of the values of the row. Values are
+// quoted so NULL is told apart from the string "NULL".
+func mysql5RowChecksum(row Row) uint32 {
+	h := crc32.NewIEEE()
+	for _, field := range row {
+		if field.Null {
+			io.WriteString(h, "NULL,")
 		} else {
-			vals[i] = MySQL5Quote(val)
+			io.WriteString(h, strconv.Quote(field.Value)+",")
+		}
+	}
+	return h.Sum32()
+}
+
+// tableLoadData returns a LOAD DATA statement which loads the table rows from
+// the data file written by the MySQL5TabDumper. Bit values are written as
+// integers, which LOAD DATA cannot assign to bit columns directly, so they are
+// read into variables and cast.
+func (g *MySQL5Dumper) tableLoadData(table *Table) string {
+	if len(table.Rows) == 0 {
+		return ""
+	}
+
+	cols := []string{}
+	sets := []string{}
+	for _, field := range table.Rows[0] {
+		if c, ok := table.Columns[field.Column]; ok && c.DataType == "bit" {
+			cols = append(cols, "@"+MySQL5Backtick(field.Column))
+			sets = append(sets, fmt.Sprintf("%s = CAST(@%s AS UNSIGNED)", MySQL5Backtick(field.Column), MySQL5Backtick(field.Column)))
+			continue
 		}
+		cols = append(cols, MySQL5Backtick(field.Column))
+	}
+	set := ""
+	if len(sets) > 0 {
+		set = " SET " + strings.Join(sets, ", ")
+	}
+	// The rows are read as binary, in the character set of each column, so the
+	// file is loaded without conversion.
+	return fmt.Sprintf(
+		"LOAD DATA LOCAL INFILE %s INTO TABLE %s CHARACTER SET binary FIELDS TERMINATED BY '\\t' ESCAPED BY '\\\\' LINES TERMINATED BY '\\n' (%s)%s;",
+		MySQL5Quote(mysql5TabFileName(table.Name)),
+		MySQL5Backtick(table.Name),
+		strings.Join(cols, ", "),
+		set,
+	)
+}
+
+// joinValues...
+func (g *MySQL5Dumper) joinValues(row Row, cols []*Column) string {
+	vals := make([]string, len(row))
+	for i, field := range row {
+		vals[i] = MySQL5EncodeValue(field, cols[i], g.args.HexBlob)
 	}
 	return strings.Join(vals, ", ")
 }
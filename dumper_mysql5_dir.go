@@ -0,0 +1,170 @@
+package dbsample
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const (
+	// mysql5DirTables is the subdirectory of the CREATE TABLE statements.
+	mysql5DirTables = "tables"
+	// mysql5DirData is the subdirectory of the table rows.
+	mysql5DirData = "data"
+)
+
+// MySQL5DirMetadata is written to metadata.json in the output directory.
+type MySQL5DirMetadata struct {
+	Database         string                    `json:"database"`
+	OriginalDatabase string                    `json:"original_database"`
+	ServerVersion    string                    `json:"server_version"`
+	DumpDate         string                    `json:"dump_date"`
+	AppVersion       string                    `json:"app_version"`
+	SchemaFile       string                    `json:"schema_file,omitempty"`
+	RoutinesFile     string                    `json:"routines_file,omitempty"`
+	ViewsFile        string                    `json:"views_file,omitempty"`
+	Tables           []*MySQL5DirTableMetadata `json:"tables"`
+}
+
+// MySQL5DirTableMetadata describes the files of a single table. The tables are
+// listed in the order they must be loaded.
+type MySQL5DirTableMetadata struct {
+	Name       string   `json:"name"`
+	SchemaFile string   `json:"schema_file"`
+	DataFile   string   `json:"data_file"`
+	Rows       int      `json:"rows"`
+	DependsOn  []string `json:"depends_on"`
+}
+
+// MySQL5DirDumper writes a directory with one file per database object:
+//
+//	schema.sql            CREATE DATABASE statement
+//	tables/<table>.sql    CREATE TABLE statement
+//	data/<table>.sql      table rows and triggers
+//	routines.sql          procedures and functions
+//	views.sql             views
+//	metadata.json         load order and row counts
+//
+// The tables are written to subdirectories so that no table name collides with
+// the other files. The writer passed to Dump is not used.
+type MySQL5DirDumper struct {
+	*MySQL5Dumper
+	dir string
+}
+
+// NewMySQL5DirDumper returns a new *MySQL5DirDumper instance.
+func NewMySQL5DirDumper(args *DumpArgs) *MySQL5DirDumper {
+	return &MySQL5DirDumper{
+		MySQL5Dumper: NewMySQL5Dumper(args),
+		dir:          args.OutputDir,
+	}
+}
+
+// Dump...
+func (g *MySQL5DirDumper) Dump(w io.Writer, db Database) error {
+	vals, err := g.templateValues(db)
+	if err != nil {
+		return err
+	}
+	for _, dir := range []string{mysql5DirTables, mysql5DirData} {
+		if err = os.MkdirAll(filepath.Join(g.dir, dir), 0755); err != nil {
+			return err
+		}
+	}
+
+	meta := &MySQL5DirMetadata{
+		Database:         db.Name(),
+		OriginalDatabase: vals.OriginalDatabaseName,
+		ServerVersion:    db.Server().Version(),
+		DumpDate:         vals.DumpDate,
+		AppVersion:       Version,
+		Tables:           []*MySQL5DirTableMetadata{},
+	}
+	none := vals
+	none.ShouldDumpDatabase = false
+	none.ShouldDumpTables = false
+	none.ShouldDumpViews = false
+	none.ShouldDumpRoutines = false
+
+	if vals.ShouldDumpDatabase {
+		v := none
+		v.ShouldDumpDatabase = true
+		if meta.SchemaFile, err = g.writeFile("schema", v); err != nil {
+			return err
+		}
+	}
+	for _, table := range vals.Tables {
+		tm := &MySQL5DirTableMetadata{
+			Name:      table.Name,
+			Rows:      len(table.Rows),
+			DependsOn: []string{},
+		}
+		for _, fk := range table.Constraints {
+			if !stringsContain(tm.DependsOn, fk.TableName) {
+				tm.DependsOn = append(tm.DependsOn, fk.TableName)
+			}
+		}
+
+		v := none
+		v.ShouldDumpTables = true
+		v.ShouldDumpSchema = true
+		v.ShouldDumpData = false
+		v.Tables = TableGraph{table}
+		if tm.SchemaFile, err = g.writeFile(path.Join(mysql5DirTables, safeFileName(table.Name)), v); err != nil {
+			return err
+		}
+		v.ShouldDumpSchema = false
+		v.ShouldDumpData = true
+		if tm.DataFile, err = g.writeFile(path.Join(mysql5DirData, safeFileName(table.Name)), v); err != nil {
+			return err
+		}
+		meta.Tables = append(meta.Tables, tm)
+	}
+	if vals.ShouldDumpRoutines {
+		v := none
+		v.ShouldDumpRoutines = true
+		if meta.RoutinesFile, err = g.writeFile("routines", v); err != nil {
+			return err
+		}
+	}
+	if vals.ShouldDumpViews {
+		v := none
+		v.ShouldDumpViews = true
+		if meta.ViewsFile, err = g.writeFile("views", v); err != nil {
+			return err
+		}
+	}
+	return g.writeMetadata(meta)
+}
+
+// writeFile executes the templates into the named file, and returns the name
+// of the file relative to the output directory, with forward slashes.
+func (g *MySQL5DirDumper) writeFile(name string, vals MySQL5DumperTemplateValues) (string, error) {
+	filename := name + ".sql" + compressExtension(g.args.Compress)
+	fw, err := NewResultWriter(filepath.Join(g.dir, filepath.FromSlash(filename)), g.args.Compress)
+	if err != nil {
+		return "", err
+	}
+	if err = g.execute(fw, vals); err != nil {
+		fw.Abort()
+		return "", err
+	}
+	return filename, fw.Close()
+}
+
+// writeMetadata...
+func (g *MySQL5DirDumper) writeMetadata(meta *MySQL5DirMetadata) error {
+	fw, err := NewResultWriter(filepath.Join(g.dir, "metadata.json"), CompressNone)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(meta); err != nil {
+		fw.Abort()
+		return err
+	}
+	return fw.Close()
+}
@@ -0,0 +1,141 @@
+package dbsample
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testDatabase is a Database which returns fixed objects, for testing the
+// dumpers without a server.
+type testDatabase struct {
+	name     string
+	server   *Server
+	tables   TableGraph
+	views    ViewGraph
+	routines RoutineGraph
+}
+
+func (db *testDatabase) Name() string        { return db.name }
+func (db *testDatabase) SetName(name string) { db.name = name }
+func (db *testDatabase) CharSet() string     { return mysql5ConnectionCharSet }
+func (db *testDatabase) Collation() string   { return mysql5ConnectionCollation }
+func (db *testDatabase) CreateSQL() (string, error) {
+	return "CREATE DATABASE " + MySQL5Backtick(db.name), nil
+}
+func (db *testDatabase) SetCreateSQL(string)             {}
+func (db *testDatabase) Tables() (TableGraph, error)     { return db.tables, nil }
+func (db *testDatabase) Views() (ViewGraph, error)       { return db.views, nil }
+func (db *testDatabase) Routines() (RoutineGraph, error) { return db.routines, nil }
+func (db *testDatabase) Server() *Server                 { return db.server }
+func (db *testDatabase) Plan(io.Writer) error            { return nil }
+func (db *testDatabase) Graph(io.Writer, string) error   { return nil }
+
+// newTestDatabase returns a database with the tables users and posts, and a
+// table named after each of the files the dir format writes.
+func newTestDatabase(args *DumpArgs) *testDatabase {
+	newTable := func(name string, rows int) *Table {
+		table := NewTable()
+		table.Name = name
+		table.CharSet = mysql5ConnectionCharSet
+		table.Collation = mysql5ConnectionCollation
+		table.CreateSQL = "CREATE TABLE " + MySQL5Backtick(name) + " (\n  `id` int(11) NOT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+		table.Columns = ColumnMap{"id": &Column{Name: "id", OrdinalPosition: 1, DataType: "int"}}
+		for i := 1; i <= rows; i++ {
+			table.Rows = append(table.Rows, Row{{Column: "id", Value: strings.Repeat("1", i)}})
+		}
+		return table
+	}
+	posts := newTable("posts", 2)
+	posts.Constraints = []*Constraint{{TableName: "users", ColumnName: "id", ReferencedColumnName: "id"}}
+	return &testDatabase{
+		name:   "blog",
+		server: &Server{conn: &ConnectionArgs{Host: "localhost"}, args: args, version: "5.7.30"},
+		tables: TableGraph{
+			newTable("users", 1),
+			posts,
+			newTable("schema", 1),
+			newTable("views", 1),
+			newTable("metadata", 1),
+		},
+		views: ViewGraph{},
+	}
+}
+
+func TestMySQL5DirDumper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	args := &DumpArgs{Format: FormatDir, OutputDir: dir, Compress: CompressNone, Views: true}
+	if err = NewMySQL5DirDumper(args).Dump(nil, newTestDatabase(args)); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{}
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, p)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	ex := []string{
+		"data/metadata.sql", "data/posts.sql", "data/schema.sql", "data/users.sql", "data/views.sql",
+		"metadata.json", "schema.sql",
+		"tables/metadata.sql", "tables/posts.sql", "tables/schema.sql", "tables/users.sql", "tables/views.sql",
+		"views.sql",
+	}
+	if !reflect.DeepEqual(files, ex) {
+		t.Errorf(`Expected '%v', got '%v'`, ex, files)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &MySQL5DirMetadata{}
+	if err = json.Unmarshal(data, meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Database != "blog" || meta.SchemaFile != "schema.sql" || meta.ViewsFile != "views.sql" || meta.RoutinesFile != "" {
+		t.Errorf(`Unexpected metadata %+v`, meta)
+	}
+	exTable := &MySQL5DirTableMetadata{
+		Name:       "posts",
+		SchemaFile: "tables/posts.sql",
+		DataFile:   "data/posts.sql",
+		Rows:       2,
+		DependsOn:  []string{"users"},
+	}
+	if len(meta.Tables) != 5 || !reflect.DeepEqual(meta.Tables[1], exTable) {
+		t.Errorf(`Expected '%+v', got '%+v'`, exTable, meta.Tables)
+	}
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "data", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "INSERT INTO `schema`") || strings.Contains(string(data), "CREATE TABLE") {
+		t.Errorf(`Expected the rows of 'schema', got '%s'`, data)
+	}
+	data, err = ioutil.ReadFile(filepath.Join(dir, "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "CREATE DATABASE") || strings.Contains(string(data), "INSERT INTO") {
+		t.Errorf(`Expected the CREATE DATABASE statement, got '%s'`, data)
+	}
+}
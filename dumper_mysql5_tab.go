@@ -0,0 +1,110 @@
+package dbsample
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// mysql5TabEscaper escapes values with the default LOAD DATA escaping rules.
+// @see https://dev.mysql.com/doc/refman/5.7/en/load-data.html
+var mysql5TabEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"\t", "\\t",
+	"\n", "\\n",
+	"\r", "\\r",
+	"\x00", "\\0",
+	"\x1a", "\\Z",
+)
+
+// MySQL5TabDumper writes a directory like mysqldump --tab, with a data file of
+// tab-separated rows for each table, and a load.sql file which creates the
+// database objects and loads each data file with LOAD DATA LOCAL INFILE in
+// dependency order. The data files are read relative to the working directory
+// of the mysql client, so load.sql must be run from the output directory.
+//
+// The writer passed to Dump is not used.
+type MySQL5TabDumper struct {
+	*MySQL5Dumper
+	dir string
+}
+
+// NewMySQL5TabDumper returns a new *MySQL5TabDumper instance.
+func NewMySQL5TabDumper(args *DumpArgs) *MySQL5TabDumper {
+	return &MySQL5TabDumper{
+		MySQL5Dumper: NewMySQL5Dumper(args),
+		dir:          args.OutputDir,
+	}
+}
+
+// Dump...
+func (g *MySQL5TabDumper) Dump(w io.Writer, db Database) error {
+	vals, err := g.templateValues(db)
+	if err != nil {
+		return err
+	}
+	vals.ShouldLoadData = true
+	if err = os.MkdirAll(g.dir, 0755); err != nil {
+		return err
+	}
+
+	for _, table := range vals.Tables {
+		if err = g.writeData(table); err != nil {
+			return err
+		}
+	}
+	fw, err := NewResultWriter(filepath.Join(g.dir, "load.sql"), CompressNone)
+	if err != nil {
+		return err
+	}
+	if err = g.execute(fw, vals); err != nil {
+		fw.Abort()
+		return err
+	}
+	return fw.Close()
+}
+
+// writeData writes the table rows to the table data file.
+func (g *MySQL5TabDumper) writeData(table *Table) error {
+	if len(table.Rows) == 0 {
+		return nil
+	}
+	fw, err := NewResultWriter(filepath.Join(g.dir, mysql5TabFileName(table.Name)), CompressNone)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(fw)
+	for _, row := range table.Rows {
+		for i, field := range row {
+			if i > 0 {
+				bw.WriteByte('\t')
+			}
+			bw.WriteString(MySQL5TabValue(field, table.Columns[field.Column]))
+		}
+		bw.WriteByte('\n')
+	}
+	if err = bw.Flush(); err != nil {
+		fw.Abort()
+		return err
+	}
+	return fw.Close()
+}
+
+// MySQL5TabValue returns the field value as written to a LOAD DATA file.
+func MySQL5TabValue(field Field, col *Column) string {
+	if field.Null {
+		return "\\N"
+	}
+	if col != nil && col.DataType == "bit" {
+		return strconv.FormatUint(fileBitValue(field.Value), 10)
+	}
+	return mysql5TabEscaper.Replace(field.Value)
+}
+
+// mysql5TabFileName returns the name of the data file of the table.
+func mysql5TabFileName(tableName string) string {
+	return safeFileName(tableName) + ".txt"
+}
@@ -0,0 +1,41 @@
+package dbsample
+
+import "testing"
+
+func TestMySQL5TabValue(t *testing.T) {
+	tests := []struct {
+		field Field
+		col   *Column
+		ex    string
+	}{
+		{Field{Value: "a\tb\nc\\d"}, &Column{DataType: "varchar"}, `a\tb\nc\\d`},
+		{Field{Value: "\x00\x1a\r"}, &Column{DataType: "blob"}, `\0\Z\r`},
+		{Field{Value: `\N`}, &Column{DataType: "varchar"}, `\\N`},
+		{Field{Null: true}, &Column{DataType: "varchar"}, `\N`},
+		{Field{Value: "\x01\x02"}, &Column{DataType: "bit"}, "258"},
+	}
+	for _, test := range tests {
+		ac := MySQL5TabValue(test.field, test.col)
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
+
+func TestMySQL5TableLoadData(t *testing.T) {
+	table := NewTable()
+	table.Name = "users"
+	table.CharSet = "latin1"
+	table.Columns = ColumnMap{
+		"id":    &Column{Name: "id", DataType: "int"},
+		"flags": &Column{Name: "flags", DataType: "bit"},
+	}
+	table.Rows = Rows{Row{{Column: "id", Value: "1"}, {Column: "flags", Value: "\x01"}}}
+	ex := "LOAD DATA LOCAL INFILE 'users.txt' INTO TABLE `users` CHARACTER SET binary " +
+		`FIELDS TERMINATED BY '\t' ESCAPED BY '\\' LINES TERMINATED BY '\n' ` +
+		"(`id`, @`flags`) SET `flags` = CAST(@`flags` AS UNSIGNED);"
+	ac := NewMySQL5Dumper(&DumpArgs{}).tableLoadData(table)
+	if ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+}
@@ -0,0 +1,32 @@
+package dbsample
+
+import "testing"
+
+func TestMySQL5DumperMixedCharSets(t *testing.T) {
+	table := NewTable()
+	table.Name = "t"
+	table.CharSet = "latin1"
+	table.Collation = "latin1_swedish_ci"
+	table.Columns = ColumnMap{
+		"id":    &Column{Name: "id", DataType: "int"},
+		"name":  &Column{Name: "name", DataType: "varchar", CharSet: "latin1"},
+		"title": &Column{Name: "title", DataType: "varchar", CharSet: "utf8mb4"},
+		"kana":  &Column{Name: "kana", DataType: "varchar", CharSet: "sjis"},
+		"note":  &Column{Name: "note", DataType: "text", CharSet: "latin1"},
+		"data":  &Column{Name: "data", DataType: "varbinary"},
+	}
+	table.Rows = Rows{Row{
+		{Column: "id", Value: "1"},
+		{Column: "name", Value: "caf\xe9\\"},
+		{Column: "title", Value: "caf\xc3\xa9's"},
+		{Column: "kana", Value: "\x83\x5c"},
+		{Column: "note", Value: ""},
+		{Column: "data", Value: "caf\xe9"},
+	}}
+
+	ex := "INSERT INTO `t` (`id`, `name`, `title`, `kana`, `note`, `data`)VALUES " +
+		"(1, _latin1 0x636166e95c, 'caf\xc3\xa9\\'s', _sjis 0x835c, _latin1 '', 'caf\xe9');\n"
+	if ac := NewMySQL5Dumper(&DumpArgs{}).tableInserts(table); ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+}
@@ -0,0 +1,83 @@
+package dbsample
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVEncoder writes rows as RFC 4180 CSV with a header line. NULL values are
+// written as the null marker, and values which equal the marker are quoted so
+// the two can be told apart. Binary values are written as hexadecimal.
+// @see https://tools.ietf.org/html/rfc4180
+type CSVEncoder struct {
+	null string
+}
+
+// NewCSVEncoder returns a new *CSVEncoder instance.
+func NewCSVEncoder(null string) *CSVEncoder {
+	return &CSVEncoder{
+		null: null,
+	}
+}
+
+// Extension...
+func (e *CSVEncoder) Extension() string {
+	return ".csv"
+}
+
+// Columns...
+func (e *CSVEncoder) Columns(cols []*Column) []*Column {
+	return cols
+}
+
+// Type...
+func (e *CSVEncoder) Type(col *Column) string {
+	switch {
+	case col.DataType == "bit":
+		return "integer"
+	case fileBinaryType(col.DataType):
+		return "hex"
+	}
+	return "string"
+}
+
+// Encode...
+func (e *CSVEncoder) Encode(w io.Writer, cols []*Column, rows Rows) error {
+	bw := bufio.NewWriter(w)
+	values := make([]string, len(cols))
+	for i, col := range cols {
+		values[i] = e.quote(col.Name)
+	}
+	bw.WriteString(strings.Join(values, ",") + "\r\n")
+	for _, row := range rows {
+		for i, field := range row {
+			values[i] = e.value(field, cols[i])
+		}
+		bw.WriteString(strings.Join(values, ",") + "\r\n")
+	}
+	return bw.Flush()
+}
+
+// value...
+func (e *CSVEncoder) value(field Field, col *Column) string {
+	switch {
+	case field.Null:
+		return e.null
+	case col.DataType == "bit":
+		return strconv.FormatUint(fileBitValue(field.Value), 10)
+	case fileBinaryType(col.DataType):
+		return hex.EncodeToString([]byte(field.Value))
+	}
+	return e.quote(field.Value)
+}
+
+// quote...
+func (e *CSVEncoder) quote(val string) string {
+	if val == e.null || strings.ContainsAny(val, ",\"\r\n") {
+		return `"` + strings.Replace(val, `"`, `""`, -1) + `"`
+	}
+	return val
+}
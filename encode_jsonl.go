@@ -0,0 +1,112 @@
+package dbsample
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/headzoo/dbsample/filters"
+	"io"
+	"strconv"
+)
+
+// JSONLEncoder writes each row as a JSON object on its own line. Values are
+// typed by the data type of their column: numbers are written as JSON numbers
+// without losing precision, JSON columns are embedded, binary values are base64
+// encoded strings, and everything else is a string.
+// @see http://jsonlines.org
+type JSONLEncoder struct{}
+
+// NewJSONLEncoder returns a new *JSONLEncoder instance.
+func NewJSONLEncoder() *JSONLEncoder {
+	return &JSONLEncoder{}
+}
+
+// Extension...
+func (e *JSONLEncoder) Extension() string {
+	return ".jsonl"
+}
+
+// Columns...
+func (e *JSONLEncoder) Columns(cols []*Column) []*Column {
+	return cols
+}
+
+// Type...
+func (e *JSONLEncoder) Type(col *Column) string {
+	switch col.DataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "year", "bit":
+		return "integer"
+	case "decimal", "numeric", "float", "double", "real":
+		return "number"
+	case "json":
+		return "json"
+	}
+	if fileBinaryType(col.DataType) {
+		return "base64"
+	}
+	return "string"
+}
+
+// Encode...
+func (e *JSONLEncoder) Encode(w io.Writer, cols []*Column, rows Rows) error {
+	bw := bufio.NewWriter(w)
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = e.string(col.Name)
+	}
+	for _, row := range rows {
+		bw.WriteByte('{')
+		for i, field := range row {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			bw.WriteString(names[i])
+			bw.WriteByte(':')
+			bw.WriteString(e.value(field, cols[i]))
+		}
+		bw.WriteString("}\n")
+	}
+	return bw.Flush()
+}
+
+// value returns the field as a JSON value. Values which do not look like
+// their type are written as strings.
+func (e *JSONLEncoder) value(field Field, col *Column) string {
+	if field.Null {
+		return "null"
+	}
+	val := field.Value
+	switch e.Type(col) {
+	case "integer":
+		if col.DataType == "bit" {
+			return strconv.FormatUint(fileBitValue(val), 10)
+		}
+		if mysql5IntegerRegexp.MatchString(val) && filters.IsJSONNumber(val) {
+			return val
+		}
+	case "number":
+		if filters.IsJSONNumber(val) {
+			return val
+		}
+	case "json":
+		if json.Valid([]byte(val)) {
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, []byte(val)); err == nil {
+				return buf.String()
+			}
+		}
+	case "base64":
+		return e.string(base64.StdEncoding.EncodeToString([]byte(val)))
+	}
+	return e.string(val)
+}
+
+// string...
+func (e *JSONLEncoder) string(val string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(val)
+	return string(bytes.TrimRight(buf.Bytes(), "\n"))
+}
@@ -0,0 +1,69 @@
+package dbsample
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	mysql5IntegerRegexp = regexp.MustCompile(`^[+-]?\d+$`)
+	mysql5DecimalRegexp = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)$`)
+)
+
+// MySQL5EncodeValue returns the field value as a literal for a column of the
+// given type. Values which do not look like their type are quoted so the
+// server can report or convert them rather than the dump failing to parse.
+// Values of columns in a character set other than the mysql5TextCharSets are
+// written as hexadecimal literals with an introducer, e.g. _latin1 0xe9.
+func MySQL5EncodeValue(field Field, col *Column, hexBlob bool) string {
+	if field.Null {
+		return "NULL"
+	}
+	dataType := ""
+	if col != nil {
+		dataType = col.DataType
+	}
+	val := field.Value
+
+	switch dataType {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint", "year":
+		if mysql5IntegerRegexp.MatchString(val) {
+			return val
+		}
+	case "decimal", "numeric":
+		if mysql5DecimalRegexp.MatchString(val) {
+			return val
+		}
+	case "float", "double", "real":
+		if _, err := strconv.ParseFloat(val, 64); err == nil {
+			return val
+		}
+	case "bit":
+		return MySQL5Bit(val)
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		if hexBlob {
+			return MySQL5Hex(val)
+		}
+	case "geometry", "point", "linestring", "polygon", "multipoint",
+		"multilinestring", "multipolygon", "geometrycollection", "geomcollection":
+		return MySQL5Geometry(val)
+	}
+	if col != nil && col.CharSet != "" && !mysql5TextCharSets[col.CharSet] {
+		return "_" + col.CharSet + " " + MySQL5Hex(val)
+	}
+	return MySQL5Quote(val)
+}
+
+// MySQL5Geometry returns a geometry value, as stored by the server, as a call
+// to ST_GeomFromWKB. The stored value is a 4 byte little-endian SRID followed
+// by the well-known binary representation of the geometry.
+func MySQL5Geometry(val string) string {
+	if len(val) < 4 {
+		return MySQL5Hex(val)
+	}
+	srid := binary.LittleEndian.Uint32([]byte(val[:4]))
+	return fmt.Sprintf("ST_GeomFromWKB(0x%s, %d)", hex.EncodeToString([]byte(val[4:])), srid)
+}
@@ -0,0 +1,45 @@
+package dbsample
+
+import "testing"
+
+func TestMySQL5EncodeValue(t *testing.T) {
+	tests := []struct {
+		dataType string
+		field    Field
+		hexBlob  bool
+		ex       string
+	}{
+		{"int", Field{Value: "42"}, true, `42`},
+		{"int", Field{Value: "-42"}, true, `-42`},
+		{"int", Field{Null: true}, true, `NULL`},
+		{"bigint", Field{Value: "18446744073709551615"}, true, `18446744073709551615`},
+		{"tinyint", Field{Value: ""}, true, `''`},
+		{"point", Field{Value: "\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\x00\x40"}, true, `ST_GeomFromWKB(0x0101000000000000000000f03f0000000000000040, 0)`},
+		{"multipoint", Field{Value: "\xe6\x10\x00\x00\x01"}, true, `ST_GeomFromWKB(0x01, 4326)`},
+		{"linestring", Field{Null: true}, true, `NULL`},
+		{"decimal", Field{Value: "1234.50"}, true, `1234.50`},
+		{"decimal", Field{Value: "-0.01"}, true, `-0.01`},
+		{"float", Field{Value: "1.5e-07"}, true, `1.5e-07`},
+		{"double", Field{Value: "3.14159"}, true, `3.14159`},
+		{"date", Field{Value: "2017-03-20"}, true, `'2017-03-20'`},
+		{"date", Field{Value: "0000-00-00"}, true, `'0000-00-00'`},
+		{"datetime", Field{Value: "0000-00-00 00:00:00"}, true, `'0000-00-00 00:00:00'`},
+		{"timestamp", Field{Value: "2017-03-20 15:50:21.560240"}, true, `'2017-03-20 15:50:21.560240'`},
+		{"time", Field{Value: "-838:59:59"}, true, `'-838:59:59'`},
+		{"year", Field{Value: "2017"}, true, `2017`},
+		{"enum", Field{Value: "it's"}, true, `'it\'s'`},
+		{"set", Field{Value: "a,b"}, true, `'a,b'`},
+		{"json", Field{Value: `{"a": "b\n"}`}, true, `'{"a": "b\\n"}'`},
+		{"bit", Field{Value: "\x05"}, true, `b'101'`},
+		{"binary", Field{Value: "\x00\xff"}, true, `0x00ff`},
+		{"blob", Field{Value: "\x00\xff"}, false, `'\0` + "\xff" + `'`},
+		{"varchar", Field{Value: "123"}, true, `'123'`},
+		{"text", Field{Null: true}, true, `NULL`},
+	}
+	for _, test := range tests {
+		ac := MySQL5EncodeValue(test.field, &Column{DataType: test.dataType}, test.hexBlob)
+		if ac != test.ex {
+			t.Errorf(`Expected '%s' for %s, got '%s'`, test.ex, test.dataType, ac)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package dbsample
+
+import (
+	"fmt"
+	"github.com/headzoo/dbsample/parquet"
+	pq "github.com/parquet-go/parquet-go"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParquetEncoder writes rows as an Apache Parquet file. The parquet schema is
+// derived from the column types, and every column is optional. The columns are
+// ordered by name, as parquet-go orders the fields of a group. Zero dates,
+// which parquet cannot represent, are written as NULL.
+type ParquetEncoder struct{}
+
+// NewParquetEncoder returns a new *ParquetEncoder instance.
+func NewParquetEncoder() *ParquetEncoder {
+	return &ParquetEncoder{}
+}
+
+// Extension...
+func (e *ParquetEncoder) Extension() string {
+	return ".parquet"
+}
+
+// Type...
+func (e *ParquetEncoder) Type(col *Column) string {
+	t := parquetNode(col).Type()
+	typ := t.Kind().String()
+	if t.ConvertedType() != nil {
+		typ += " (" + t.LogicalType().String() + ")"
+	}
+	return typ
+}
+
+// Columns returns the columns ordered by name, which is the order parquet-go
+// writes the fields of a group in.
+func (e *ParquetEncoder) Columns(cols []*Column) []*Column {
+	names := make(map[string]*Column, len(cols))
+	for _, col := range cols {
+		names[col.Name] = col
+	}
+	ordered := make([]*Column, 0, len(cols))
+	for _, field := range parquetGroup(cols).Fields() {
+		ordered = append(ordered, names[field.Name()])
+	}
+	return ordered
+}
+
+// Encode...
+func (e *ParquetEncoder) Encode(w io.Writer, cols []*Column, rows Rows) error {
+	pw := parquet.NewWriter(w, parquetGroup(cols))
+	index := make(map[string]int, len(cols))
+	for i, col := range cols {
+		index[col.Name] = i
+	}
+	fields := pw.Fields()
+	values := make([]interface{}, len(fields))
+	for n, row := range rows {
+		for i, pf := range fields {
+			col := index[pf.Name()]
+			v, err := parquetValue(row[col], cols[col], pf.Type().Kind())
+			if err != nil {
+				return fmt.Errorf("row %d, column %s: %s", n+1, MySQL5Backtick(cols[col].Name), err)
+			}
+			values[i] = v
+		}
+		if err := pw.Write(values); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
+
+// parquetGroup returns the parquet schema of the columns. Every column is
+// optional.
+func parquetGroup(cols []*Column) pq.Group {
+	group := pq.Group{}
+	for _, col := range cols {
+		group[col.Name] = pq.Optional(parquetNode(col))
+	}
+	return group
+}
+
+// parquetNode returns the parquet type of a column.
+func parquetNode(col *Column) pq.Node {
+	unsigned := strings.Contains(col.Type, "unsigned")
+	switch col.DataType {
+	case "tinyint", "smallint", "mediumint", "year":
+		return pq.Leaf(pq.Int32Type)
+	case "int", "integer":
+		if unsigned {
+			return pq.Leaf(pq.Int64Type)
+		}
+		return pq.Leaf(pq.Int32Type)
+	case "bigint":
+		if unsigned {
+			return pq.Uint(64)
+		}
+		return pq.Leaf(pq.Int64Type)
+	case "bit":
+		return pq.Leaf(pq.Int64Type)
+	case "float":
+		return pq.Leaf(pq.FloatType)
+	case "double", "real":
+		return pq.Leaf(pq.DoubleType)
+	case "decimal", "numeric":
+		if parquetIntDecimal(col) {
+			return pq.Decimal(int(col.NumericScale), int(col.NumericPrecision), pq.Int64Type)
+		}
+		return pq.String()
+	case "date":
+		return pq.Date()
+	case "datetime", "timestamp":
+		return pq.Timestamp(pq.Microsecond)
+	case "enum":
+		return pq.Enum()
+	case "json":
+		return pq.JSON()
+	}
+	if fileBinaryType(col.DataType) {
+		return pq.Leaf(pq.ByteArrayType)
+	}
+	return pq.String()
+}
+
+// parquetIntDecimal returns whether the decimal column fits an INT64 decimal.
+func parquetIntDecimal(col *Column) bool {
+	return col.NumericPrecision <= 18
+}
+
+// parquetValue returns the field as a value of the given parquet kind.
+func parquetValue(field Field, col *Column, kind pq.Kind) (interface{}, error) {
+	if field.Null {
+		return nil, nil
+	}
+	val := field.Value
+	switch col.DataType {
+	case "bit":
+		return int64(fileBitValue(val)), nil
+	case "decimal", "numeric":
+		if parquetIntDecimal(col) {
+			return parquetDecimal(val, int(col.NumericScale))
+		}
+	case "date":
+		if strings.HasPrefix(val, "0000-00-00") {
+			return nil, nil
+		}
+		t, err := time.Parse("2006-01-02", val)
+		if err != nil {
+			return nil, err
+		}
+		return int32(t.Unix() / 86400), nil
+	case "datetime", "timestamp":
+		if strings.HasPrefix(val, "0000-00-00") {
+			return nil, nil
+		}
+		t, err := time.Parse("2006-01-02 15:04:05.999999", val)
+		if err != nil {
+			return nil, err
+		}
+		return t.Unix()*1000000 + int64(t.Nanosecond()/1000), nil
+	case "bigint":
+		if strings.Contains(col.Type, "unsigned") {
+			n, err := strconv.ParseUint(val, 10, 64)
+			return int64(n), err
+		}
+	}
+
+	switch kind {
+	case pq.Int32:
+		n, err := strconv.ParseInt(val, 10, 32)
+		return int32(n), err
+	case pq.Int64:
+		n, err := strconv.ParseInt(val, 10, 64)
+		return n, err
+	case pq.Float:
+		f, err := strconv.ParseFloat(val, 32)
+		return float32(f), err
+	case pq.Double:
+		f, err := strconv.ParseFloat(val, 64)
+		return f, err
+	}
+	return []byte(val), nil
+}
+
+// parquetDecimal returns a decimal value as an unscaled integer.
+func parquetDecimal(val string, scale int) (int64, error) {
+	if !mysql5DecimalRegexp.MatchString(val) {
+		return 0, fmt.Errorf(`invalid decimal "%s"`, val)
+	}
+	digits := strings.TrimLeft(val, "+-")
+	frac := ""
+	if i := strings.Index(digits, "."); i != -1 {
+		digits, frac = digits[:i], digits[i+1:]
+	}
+	if len(frac) > scale {
+		frac = frac[:scale]
+	}
+	frac += strings.Repeat("0", scale-len(frac))
+	n, err := strconv.ParseInt(digits+frac, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasPrefix(val, "-") {
+		n = -n
+	}
+	return n, nil
+}
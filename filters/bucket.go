@@ -0,0 +1,47 @@
+package filters
+
+import (
+	"errors"
+	"math/big"
+)
+
+// BucketFilter rounds numeric column values to the nearest multiple of a
+// bucket size.
+type BucketFilter struct {
+}
+
+// NewBucketFilter returns a new *BucketFilter instance.
+func NewBucketFilter() *BucketFilter {
+	return &BucketFilter{}
+}
+
+// Filter...
+func (f *BucketFilter) Filter(value *string, ctx *Context, args []string) error {
+	if *value == "" {
+		return nil
+	}
+	v, err := numericParse("bucket", *value, ctx)
+	if err != nil {
+		return err
+	}
+	size, _ := new(big.Rat).SetString(args[0])
+	n := numericRoundInt(v.Quo(v, size))
+	*value, err = numericFormat("bucket", v.Mul(new(big.Rat).SetInt(n), size), ctx)
+	return err
+}
+
+// ValidateArgs...
+func (f *BucketFilter) ValidateArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New(`Filter "bucket" expects exactly 1 argument.`)
+	}
+	if size, ok := new(big.Rat).SetString(args[0]); !ok || size.Sign() <= 0 {
+		return errors.New(`Filter "bucket" expects the bucket size to be a positive number.`)
+	}
+	return nil
+}
+
+// Usage...
+func (f *BucketFilter) Usage() string {
+	return `bucket table.column <size>`
+}
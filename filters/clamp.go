@@ -0,0 +1,54 @@
+package filters
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ClampFilter limits numeric column values to a range.
+type ClampFilter struct {
+}
+
+// NewClampFilter returns a new *ClampFilter instance.
+func NewClampFilter() *ClampFilter {
+	return &ClampFilter{}
+}
+
+// Filter...
+func (f *ClampFilter) Filter(value *string, ctx *Context, args []string) error {
+	if *value == "" {
+		return nil
+	}
+	v, err := numericParse("clamp", *value, ctx)
+	if err != nil {
+		return err
+	}
+	min, _ := new(big.Rat).SetString(args[0])
+	max, _ := new(big.Rat).SetString(args[1])
+	*value, err = numericFormat("clamp", numericClamp(v, min, max), ctx)
+	return err
+}
+
+// ValidateArgs...
+func (f *ClampFilter) ValidateArgs(args []string) error {
+	if len(args) != 2 {
+		return errors.New(`Filter "clamp" expects exactly 2 arguments.`)
+	}
+	min, ok := new(big.Rat).SetString(args[0])
+	if !ok {
+		return errors.New(`Filter "clamp" expects the minimum to be a number.`)
+	}
+	max, ok := new(big.Rat).SetString(args[1])
+	if !ok {
+		return errors.New(`Filter "clamp" expects the maximum to be a number.`)
+	}
+	if min.Cmp(max) > 0 {
+		return errors.New(`Filter "clamp" expects the minimum to be less than the maximum.`)
+	}
+	return nil
+}
+
+// Usage...
+func (f *ClampFilter) Usage() string {
+	return `clamp table.column <min> <max>`
+}
@@ -0,0 +1,126 @@
+package filters
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateShiftDateLayout     = "2006-01-02"
+	dateShiftDateTimeLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	dateShiftMinDateTime  = time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)
+	dateShiftMaxDateTime  = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+	dateShiftMinTimestamp = time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC)
+	dateShiftMaxTimestamp = time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC)
+)
+
+// DateShiftFilter moves date values by a random number of days. The number of
+// days is derived from the value of a key column, e.g. the row's user_id, so
+// every date belonging to the same entity moves by the same offset.
+type DateShiftFilter struct {
+	salt []byte
+}
+
+// NewDateShiftFilter returns a new *DateShiftFilter instance.
+func NewDateShiftFilter() (*DateShiftFilter, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &DateShiftFilter{
+		salt: salt,
+	}, nil
+}
+
+// Filter...
+func (f *DateShiftFilter) Filter(value *string, ctx *Context, args []string) error {
+	if *value == "" || strings.HasPrefix(*value, "0000-00-00") {
+		return nil
+	}
+	key, ok := ctx.Row[args[0]]
+	if !ok {
+		return fmt.Errorf(`Filter "dateshift" key column "%s" not found in table "%s".`, args[0], ctx.TableName)
+	}
+	days, _ := strconv.Atoi(args[1])
+	offset := f.offset(key, days)
+
+	switch ctx.DataType {
+	case "date":
+		t, err := time.Parse(dateShiftDateLayout, *value)
+		if err != nil {
+			return err
+		}
+		t = dateShiftClamp(t.AddDate(0, 0, offset), dateShiftMinDateTime, dateShiftMaxDateTime)
+		*value = t.Format(dateShiftDateLayout)
+	case "datetime", "timestamp":
+		frac := ""
+		v := *value
+		if i := strings.IndexByte(v, '.'); i != -1 {
+			v, frac = v[:i], v[i:]
+		}
+		t, err := time.Parse(dateShiftDateTimeLayout, v)
+		if err != nil {
+			return err
+		}
+		t = t.AddDate(0, 0, offset)
+		if ctx.DataType == "timestamp" {
+			t = dateShiftClamp(t, dateShiftMinTimestamp, dateShiftMaxTimestamp)
+		} else {
+			t = dateShiftClamp(t, dateShiftMinDateTime, dateShiftMaxDateTime)
+		}
+		*value = t.Format(dateShiftDateTimeLayout) + frac
+	default:
+		return fmt.Errorf(`Filter "dateshift" cannot be used on %s column "%s.%s".`, ctx.DataType, ctx.TableName, ctx.ColumnName)
+	}
+	return nil
+}
+
+// offset returns the number of days, between -days and days, to shift the
+// dates belonging to key. The offset is never zero.
+func (f *DateShiftFilter) offset(key string, days int) int {
+	h := sha256.New()
+	h.Write(f.salt)
+	h.Write([]byte(key))
+	n := int(binary.BigEndian.Uint64(h.Sum(nil)) % uint64(days*2))
+	if n < days {
+		return n - days
+	}
+	return n - days + 1
+}
+
+// ValidateArgs...
+func (f *DateShiftFilter) ValidateArgs(args []string) error {
+	if len(args) != 2 {
+		return errors.New(`Filter "dateshift" expects exactly 2 arguments.`)
+	}
+	days, err := strconv.Atoi(args[1])
+	if err != nil || days < 1 {
+		return errors.New(`Filter "dateshift" expects the number of days to be a positive integer.`)
+	}
+	return nil
+}
+
+// Usage...
+func (f *DateShiftFilter) Usage() string {
+	return `dateshift table.column <key-column> <max-days>`
+}
+
+// dateShiftClamp...
+func dateShiftClamp(t, min, max time.Time) time.Time {
+	if t.Before(min) {
+		return min
+	}
+	if t.After(max) {
+		return max
+	}
+	return t
+}
@@ -0,0 +1,81 @@
+package filters
+
+import "testing"
+
+func TestDateShiftFilter(t *testing.T) {
+	f, err := NewDateShiftFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"user_id", "30"}
+	tests := map[string]string{
+		"date":      "2017-03-20",
+		"datetime":  "2017-03-20 15:50:21.560240",
+		"timestamp": "2017-03-20 15:50:21",
+	}
+	for dataType, orig := range tests {
+		ctx := &Context{
+			TableName:  "users",
+			ColumnName: "created_at",
+			DataType:   dataType,
+			Row:        map[string]string{"user_id": "42"},
+		}
+		a, b := orig, orig
+		if err := f.Filter(&a, ctx, args); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Filter(&b, ctx, args); err != nil {
+			t.Fatal(err)
+		}
+		if a == orig {
+			t.Errorf(`Expected '%s' to be shifted`, orig)
+		}
+		if a != b {
+			t.Errorf(`Expected '%s', got '%s'`, a, b)
+		}
+		if len(a) != len(orig) || a[10:] != orig[10:] {
+			t.Errorf(`Expected '%s' to keep the format of '%s'`, a, orig)
+		}
+	}
+}
+
+func TestDateShiftFilterZeroDate(t *testing.T) {
+	f, err := NewDateShiftFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &Context{
+		DataType: "datetime",
+		Row:      map[string]string{"user_id": "42"},
+	}
+	for _, orig := range []string{"", "0000-00-00 00:00:00"} {
+		v := orig
+		if err := f.Filter(&v, ctx, []string{"user_id", "30"}); err != nil {
+			t.Fatal(err)
+		}
+		if v != orig {
+			t.Errorf(`Expected '%s', got '%s'`, orig, v)
+		}
+	}
+}
+
+func TestDateShiftFilterTimestampRange(t *testing.T) {
+	f, err := NewDateShiftFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &Context{
+		DataType: "timestamp",
+		Row:      map[string]string{"user_id": "42"},
+	}
+	for _, key := range []string{"1", "2", "3", "4", "5", "6", "7", "8"} {
+		v := "2038-01-19 03:14:07"
+		ctx.Row["user_id"] = key
+		if err := f.Filter(&v, ctx, []string{"user_id", "365"}); err != nil {
+			t.Fatal(err)
+		}
+		if v > "2038-01-19 03:14:07" {
+			t.Errorf(`Expected '%s' to fit a timestamp column`, v)
+		}
+	}
+}
@@ -14,7 +14,7 @@ func NewEmptyFilter() *EmptyFilter {
 }
 
 // Filter...
-func (f *EmptyFilter) Filter(value *string, dataType string, maxLength int64, args []string) error {
+func (f *EmptyFilter) Filter(value *string, ctx *Context, args []string) error {
 	*value = ""
 	return nil
 }
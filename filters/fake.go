@@ -0,0 +1,100 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+)
+
+var (
+	fakeFirstNames = []string{
+		"Alex", "Bailey", "Casey", "Dana", "Elliot", "Frankie", "Gray", "Harper",
+		"Indigo", "Jordan", "Kai", "Logan", "Morgan", "Noel", "Parker", "Quinn",
+		"Reese", "Sage", "Taylor", "Val",
+	}
+	fakeLastNames = []string{
+		"Adams", "Brooks", "Carter", "Diaz", "Evans", "Foster", "Garcia", "Hughes",
+		"Ito", "Jensen", "Kim", "Lopez", "Miller", "Novak", "Olsen", "Patel",
+		"Rossi", "Silva", "Turner", "Walsh",
+	}
+	fakeWords = []string{
+		"amber", "birch", "cedar", "delta", "ember", "fjord", "grove", "harbor",
+		"island", "juniper", "kestrel", "lagoon", "meadow", "nimbus", "orchid",
+		"prairie", "quartz", "river", "summit", "tundra",
+	}
+)
+
+// fakeKinds are the kinds of value the fake filter generates.
+var fakeKinds = map[string]func(r *rand.Rand) string{
+	"name": func(r *rand.Rand) string {
+		return fakePick(r, fakeFirstNames) + " " + fakePick(r, fakeLastNames)
+	},
+	"first_name": func(r *rand.Rand) string {
+		return fakePick(r, fakeFirstNames)
+	},
+	"last_name": func(r *rand.Rand) string {
+		return fakePick(r, fakeLastNames)
+	},
+	"email": func(r *rand.Rand) string {
+		return fmt.Sprintf(
+			"%s.%s%d@example.com",
+			strings.ToLower(fakePick(r, fakeFirstNames)),
+			strings.ToLower(fakePick(r, fakeLastNames)),
+			r.Intn(1000),
+		)
+	},
+	"phone": func(r *rand.Rand) string {
+		// 555-0100 through 555-0199 are reserved for fictional use.
+		return fmt.Sprintf("555-01%02d", r.Intn(100))
+	},
+	"word": func(r *rand.Rand) string {
+		return fakePick(r, fakeWords)
+	},
+}
+
+// FakeFilter replaces column values with realistic looking fake values. The
+// fake value is derived from the original, so equal values are replaced by
+// equal fakes and the filtered values can still be joined.
+type FakeFilter struct {
+}
+
+// NewFakeFilter returns a new *FakeFilter instance.
+func NewFakeFilter() *FakeFilter {
+	return &FakeFilter{}
+}
+
+// Filter...
+func (f *FakeFilter) Filter(value *string, ctx *Context, args []string) error {
+	if *value == "" {
+		return nil
+	}
+	h := fnv.New64a()
+	h.Write([]byte(*value))
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+	*value = fakeKinds[args[0]](r)
+	*value = truncate(*value, ctx)
+	return nil
+}
+
+// ValidateArgs...
+func (f *FakeFilter) ValidateArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New(`Filter "fake" expects exactly 1 argument.`)
+	}
+	if _, ok := fakeKinds[args[0]]; !ok {
+		return fmt.Errorf(`Filter "fake" cannot generate "%s", expected name, first_name, last_name, email, phone or word.`, args[0])
+	}
+	return nil
+}
+
+// Usage...
+func (f *FakeFilter) Usage() string {
+	return `fake table.column <name|first_name|last_name|email|phone|word>`
+}
+
+// fakePick...
+func fakePick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
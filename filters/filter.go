@@ -7,11 +7,38 @@ import (
 
 // Filter represents an object which filters table column values.
 type Filter interface {
-	Filter(value *string, dataType string, maxLength int64, args []string) error
+	Filter(value *string, ctx *Context, args []string) error
 	ValidateArgs(args []string) error
 	Usage() string
 }
 
+// Context stores the details of the column value being filtered.
+type Context struct {
+	TableName  string
+	ColumnName string
+	DataType   string
+	ColumnType string
+	MaxLength  int64
+	Precision  int64
+	Scale      int64
+	// Row holds the unfiltered values of every column in the row.
+	Row map[string]string
+}
+
+// truncate returns the value cut to the maximum length of the column. Values
+// inside JSON documents have no maximum length, their MaxLength is the length
+// of the original value.
+func truncate(value string, ctx *Context) string {
+	if ctx.MaxLength <= 0 || ctx.DataType == "json" {
+		return value
+	}
+	runes := []rune(value)
+	if int64(len(runes)) > ctx.MaxLength {
+		return string(runes[:ctx.MaxLength])
+	}
+	return value
+}
+
 // FilterCommand stores the details of a filter command.
 type FilterCommand struct {
 	FilterName string
@@ -35,10 +62,26 @@ func NewFilterController() *FilterController {
 
 // Load the filters.
 func (c *FilterController) Load() error {
+	dateShift, err := NewDateShiftFilter()
+	if err != nil {
+		return err
+	}
+	hash, err := NewHashFilter()
+	if err != nil {
+		return err
+	}
 	c.loaded = map[string]Filter{
-		"empty":  NewEmptyFilter(),
-		"repeat": NewRepeatFilter(),
+		"empty":     NewEmptyFilter(),
+		"repeat":    NewRepeatFilter(),
+		"dateshift": dateShift,
+		"noise":     NewNoiseFilter(),
+		"bucket":    NewBucketFilter(),
+		"clamp":     NewClampFilter(),
+		"hash":      hash,
+		"mask":      NewMaskFilter(),
+		"fake":      NewFakeFilter(),
 	}
+	c.loaded["json"] = NewJSONFilter(c.loaded)
 	return nil
 }
 
@@ -54,7 +97,7 @@ func (c *FilterController) Usage() []string {
 // SetCommands...
 func (c *FilterController) SetCommands(cmds []string) (err error) {
 	for _, cmd := range cmds {
-		parts := strings.Split(cmd, " ")
+		parts := splitCommand(cmd)
 		if len(parts) < 2 {
 			err = fmt.Errorf(`Invalid filter "%s"`, cmd)
 			return
@@ -85,14 +128,92 @@ func (c *FilterController) SetCommands(cmds []string) (err error) {
 	return
 }
 
-// Filter...
-func (c *FilterController) Filter(value *string, tableName, columnName, dataType string, maxLength int64) (err error) {
+// HasFilter returns whether one or more filters are applied to the column.
+func (c *FilterController) HasFilter(tableName, columnName string) bool {
 	for _, cmd := range c.cmds {
 		if cmd.TableName == tableName && cmd.ColumnName == columnName {
-			if err = c.loaded[cmd.FilterName].Filter(value, dataType, maxLength, cmd.Args); err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CoversColumn returns whether a filter which replaces the whole value is
+// applied to the column. A json filter only covers the values it selects.
+func (c *FilterController) CoversColumn(tableName, columnName string) bool {
+	for _, cmd := range c.cmds {
+		if cmd.TableName == tableName && cmd.ColumnName == columnName && cmd.FilterName != "json" {
+			return true
+		}
+	}
+	return false
+}
+
+// CoversJSONLeaf returns whether a json filter applied to the column selects
+// the leaf.
+func (c *FilterController) CoversJSONLeaf(tableName, columnName string, leaf *JSONLeaf) bool {
+	for _, cmd := range c.cmds {
+		if cmd.TableName != tableName || cmd.ColumnName != columnName || cmd.FilterName != "json" {
+			continue
+		}
+		if path, err := jsonParsePath(cmd.Args[0]); err == nil && jsonPathMatch(path, leaf.legs) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter...
+func (c *FilterController) Filter(value *string, ctx *Context) (err error) {
+	for _, cmd := range c.cmds {
+		if cmd.TableName == ctx.TableName && cmd.ColumnName == ctx.ColumnName {
+			if err = c.loaded[cmd.FilterName].Filter(value, ctx, cmd.Args); err != nil {
 				return
 			}
 		}
 	}
 	return
 }
+
+// splitCommand splits a filter command into its parts at white space. White
+// space inside single or double quotes does not split a part, e.g. in the path
+// `$."home phone"`, and the quotes of a part which is quoted as a whole are
+// removed.
+func splitCommand(cmd string) []string {
+	parts := []string{}
+	b := &strings.Builder{}
+	var quote rune
+	inPart := false
+	for _, r := range cmd {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ' ' || r == '\t':
+			if inPart {
+				parts = append(parts, splitCommandUnquote(b.String()))
+				b.Reset()
+				inPart = false
+			}
+			continue
+		}
+		b.WriteRune(r)
+		inPart = true
+	}
+	if inPart {
+		parts = append(parts, splitCommandUnquote(b.String()))
+	}
+	return parts
+}
+
+// splitCommandUnquote removes the quotes around a part quoted as a whole.
+func splitCommandUnquote(part string) string {
+	if len(part) >= 2 && (part[0] == '"' || part[0] == '\'') && part[len(part)-1] == part[0] &&
+		!strings.ContainsRune(part[1:len(part)-1], rune(part[0])) {
+		return part[1 : len(part)-1]
+	}
+	return part
+}
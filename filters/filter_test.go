@@ -0,0 +1,114 @@
+package filters
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := map[string][]string{
+		"empty users.email":                           {"empty", "users.email"},
+		"repeat  users.email   X":                     {"repeat", "users.email", "X"},
+		`json users.profile $."home phone" empty`:     {"json", "users.profile", `$."home phone"`, "empty"},
+		`repeat users.name "X Y"`:                     {"repeat", "users.name", "X Y"},
+		`json users.profile '$.a b' mask 4 '#'`:       {"json", "users.profile", "$.a b", "mask", "4", "#"},
+		`json users.profile $."a"."b c"[0] hash salt`: {"json", "users.profile", `$."a"."b c"[0]`, "hash", "salt"},
+	}
+	for cmd, ex := range tests {
+		ac := splitCommand(cmd)
+		if !reflect.DeepEqual(ex, ac) {
+			t.Errorf(`Expected '%q', got '%q'`, ex, ac)
+		}
+	}
+}
+
+func TestHashFilter(t *testing.T) {
+	f, err := NewHashFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewHashFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &Context{DataType: "varchar", MaxLength: 40}
+	hash := func(f *HashFilter, value string, args []string) string {
+		if err := f.Filter(&value, ctx, args); err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+
+	a, b, c := hash(f, "bob@example.com", nil), hash(f, "bob@example.com", nil), hash(f, "alice@example.com", nil)
+	if a != b || a == c || len(a) != 40 {
+		t.Errorf(`Expected equal 40 character hashes of equal values, got '%s', '%s', '%s'`, a, b, c)
+	}
+	// Without a salt each run hashes differently, and with the same salt the
+	// hashes are stable.
+	if ac := hash(g, "bob@example.com", nil); ac == a {
+		t.Errorf(`Expected a hash other than '%s'`, a)
+	}
+	if a, b = hash(f, "bob@example.com", []string{"pepper"}), hash(g, "bob@example.com", []string{"pepper"}); a != b {
+		t.Errorf(`Expected '%s', got '%s'`, a, b)
+	}
+
+	v := "42"
+	if err = f.Filter(&v, &Context{DataType: "int", TableName: "users", ColumnName: "age"}, nil); err == nil {
+		t.Error("Expected an error hashing an int column")
+	}
+	if err = f.ValidateArgs([]string{""}); err == nil {
+		t.Error("Expected an error for an empty salt")
+	}
+}
+
+func TestMaskFilter(t *testing.T) {
+	f := NewMaskFilter()
+	tests := []struct {
+		in   string
+		args []string
+		ex   string
+	}{
+		{"555-1234", []string{"4"}, "***-1234"},
+		{"4111 1111 1111 1111", []string{"4", "X"}, "XXXX XXXX XXXX 1111"},
+		{"ab", []string{"4"}, "ab"},
+		{"bob@example.com", []string{"0"}, "***@*******.***"},
+	}
+	for _, test := range tests {
+		if err := f.ValidateArgs(test.args); err != nil {
+			t.Fatal(err)
+		}
+		ac := test.in
+		if err := f.Filter(&ac, &Context{}, test.args); err != nil {
+			t.Fatal(err)
+		}
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
+
+func TestFakeFilter(t *testing.T) {
+	f := NewFakeFilter()
+	if err := f.ValidateArgs([]string{"ssn"}); err == nil {
+		t.Error("Expected an error for an unknown kind")
+	}
+	a, b := "Sean", "Sean"
+	ctx := &Context{DataType: "varchar", MaxLength: 100}
+	for _, v := range []*string{&a, &b} {
+		if err := f.Filter(v, ctx, []string{"email"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a != b || !strings.HasSuffix(a, "@example.com") {
+		t.Errorf(`Expected equal fake emails, got '%s' and '%s'`, a, b)
+	}
+	v := "555-867-5309"
+	ctx.MaxLength = 5
+	if err := f.Filter(&v, ctx, []string{"phone"}); err != nil {
+		t.Fatal(err)
+	}
+	if v != "555-0" {
+		t.Errorf(`Expected '%s', got '%s'`, "555-0", v)
+	}
+}
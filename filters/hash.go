@@ -0,0 +1,82 @@
+package filters
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// hashDataTypes are the data types which can hold the hex encoded hash.
+var hashDataTypes = map[string]bool{
+	"char":       true,
+	"varchar":    true,
+	"tinytext":   true,
+	"text":       true,
+	"mediumtext": true,
+	"longtext":   true,
+	"binary":     true,
+	"varbinary":  true,
+	"tinyblob":   true,
+	"blob":       true,
+	"mediumblob": true,
+	"longblob":   true,
+	"json":       true,
+}
+
+// HashFilter replaces column values with the hex encoded SHA-256 hash of a
+// salt and the value. Equal values have equal hashes, so the filtered values
+// can still be joined and counted. The salt is random for each run, so the
+// hashes cannot be reversed by hashing every possible value, unless a fixed
+// salt is given to keep the hashes stable across runs.
+type HashFilter struct {
+	salt []byte
+}
+
+// NewHashFilter returns a new *HashFilter instance.
+func NewHashFilter() (*HashFilter, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &HashFilter{
+		salt: salt,
+	}, nil
+}
+
+// Filter...
+func (f *HashFilter) Filter(value *string, ctx *Context, args []string) error {
+	if !hashDataTypes[ctx.DataType] {
+		return fmt.Errorf(`Filter "hash" cannot be used on %s column "%s.%s".`, ctx.DataType, ctx.TableName, ctx.ColumnName)
+	}
+	if *value == "" {
+		return nil
+	}
+	salt := f.salt
+	if len(args) > 0 {
+		salt = []byte(args[0])
+	}
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(*value))
+	*value = hex.EncodeToString(h.Sum(nil))
+	*value = truncate(*value, ctx)
+	return nil
+}
+
+// ValidateArgs...
+func (f *HashFilter) ValidateArgs(args []string) error {
+	if len(args) > 1 {
+		return errors.New(`Filter "hash" expects at most 1 argument.`)
+	}
+	if len(args) == 1 && args[0] == "" {
+		return errors.New(`Filter "hash" salt must not be empty.`)
+	}
+	return nil
+}
+
+// Usage...
+func (f *HashFilter) Usage() string {
+	return `hash table.column [<salt>]`
+}
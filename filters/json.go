@@ -0,0 +1,358 @@
+package filters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSONFilter applies another filter to the values in a JSON document which
+// are selected by a path expression, e.g. "$.phone" or "$.addresses[*].street".
+// The remainder of the document is left untouched.
+type JSONFilter struct {
+	filters map[string]Filter
+}
+
+// NewJSONFilter returns a new *JSONFilter instance. The filters are those which
+// may be applied to the selected values.
+func NewJSONFilter(filters map[string]Filter) *JSONFilter {
+	return &JSONFilter{
+		filters: filters,
+	}
+}
+
+// Filter...
+func (f *JSONFilter) Filter(value *string, ctx *Context, args []string) error {
+	if *value == "" || *value == "null" {
+		return nil
+	}
+	if !json.Valid([]byte(*value)) {
+		return fmt.Errorf(`Filter "json" cannot parse the value of "%s.%s".`, ctx.TableName, ctx.ColumnName)
+	}
+	path, _ := jsonParsePath(args[0])
+	scan := &jsonScanner{doc: *value}
+	scan.value(nil)
+
+	// Only the selected leaves are replaced, so key order, formatting and the
+	// remainder of the document are kept byte for byte.
+	filter := f.filters[args[1]]
+	b := &strings.Builder{}
+	last := 0
+	for _, leaf := range scan.leaves {
+		if !jsonPathMatch(path, leaf.path) {
+			continue
+		}
+		raw := (*value)[leaf.start:leaf.end]
+		filtered, err := f.filterLeaf(raw, filter, ctx, args[2:])
+		if err != nil {
+			return err
+		}
+		b.WriteString((*value)[last:leaf.start])
+		b.WriteString(filtered)
+		last = leaf.end
+	}
+	b.WriteString((*value)[last:])
+	*value = b.String()
+	return nil
+}
+
+// filterLeaf applies the filter to the raw JSON scalar, and returns the raw
+// JSON of the filtered value. Strings stay strings and numbers stay numbers,
+// an emptied number becomes 0. Booleans and nulls are not filtered.
+func (f *JSONFilter) filterLeaf(raw string, filter Filter, ctx *Context, args []string) (string, error) {
+	leafCtx := *ctx
+	switch {
+	case raw[0] == '"':
+		var s string
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return "", err
+		}
+		leafCtx.DataType = "json"
+		leafCtx.ColumnType = "json"
+		leafCtx.MaxLength = int64(utf8.RuneCountInString(s))
+		if err := filter.Filter(&s, &leafCtx, args); err != nil {
+			return "", err
+		}
+		buff := &bytes.Buffer{}
+		enc := json.NewEncoder(buff)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(s); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(buff.String(), "\n"), nil
+	case raw == "true" || raw == "false" || raw == "null":
+		return raw, nil
+	}
+
+	leafCtx.DataType = "double"
+	leafCtx.ColumnType = "double"
+	leafCtx.MaxLength = int64(len(raw))
+	if _, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		leafCtx.DataType = "bigint"
+		leafCtx.ColumnType = "bigint(20)"
+	}
+	s := raw
+	if err := filter.Filter(&s, &leafCtx, args); err != nil {
+		return "", err
+	}
+	if s == "" {
+		return "0", nil
+	}
+	if !IsJSONNumber(s) {
+		return "", fmt.Errorf(`Filter "json" cannot write "%s" to a number in "%s.%s".`, s, ctx.TableName, ctx.ColumnName)
+	}
+	return s, nil
+}
+
+// ValidateArgs...
+func (f *JSONFilter) ValidateArgs(args []string) error {
+	if len(args) < 2 {
+		return errors.New(`Filter "json" expects at least 2 arguments.`)
+	}
+	if _, err := jsonParsePath(args[0]); err != nil {
+		return err
+	}
+	filter, ok := f.filters[args[1]]
+	if !ok || args[1] == "json" {
+		return fmt.Errorf(`Filter "json" cannot apply filter "%s".`, args[1])
+	}
+	return filter.ValidateArgs(args[2:])
+}
+
+// Usage...
+func (f *JSONFilter) Usage() string {
+	return `json table.column <path> <filter> [<args>...]`
+}
+
+// jsonNumberRegexp matches a JSON number.
+var jsonNumberRegexp = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+
+// IsJSONNumber returns whether s is written as a JSON number.
+func IsJSONNumber(s string) bool {
+	return jsonNumberRegexp.MatchString(s)
+}
+
+// jsonPathWildcard matches every member of an object or element of an array.
+const jsonPathWildcard = "*"
+
+// jsonPathLeg is one step of a path, either an object key or an array index.
+type jsonPathLeg struct {
+	key   string
+	index int
+	array bool
+}
+
+// jsonParsePath parses a MySQL style JSON path, e.g. `$.a."b c"[0].*`.
+func jsonParsePath(path string) ([]jsonPathLeg, error) {
+	invalid := fmt.Errorf(`Filter "json" invalid path "%s".`, path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid
+	}
+	legs := []jsonPathLeg{}
+	p := path[1:]
+	for len(p) > 0 {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			if strings.HasPrefix(p, `"`) {
+				end := strings.IndexByte(p[1:], '"')
+				if end == -1 {
+					return nil, invalid
+				}
+				legs = append(legs, jsonPathLeg{key: p[1 : end+1]})
+				p = p[end+2:]
+				continue
+			}
+			end := strings.IndexAny(p, ".[")
+			if end == -1 {
+				end = len(p)
+			}
+			if end == 0 {
+				return nil, invalid
+			}
+			legs = append(legs, jsonPathLeg{key: p[:end]})
+			p = p[end:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end == -1 {
+				return nil, invalid
+			}
+			leg := jsonPathLeg{array: true, key: p[1:end]}
+			if leg.key != jsonPathWildcard {
+				i, err := strconv.Atoi(leg.key)
+				if err != nil || i < 0 {
+					return nil, invalid
+				}
+				leg.index = i
+			}
+			legs = append(legs, leg)
+			p = p[end+1:]
+		default:
+			return nil, invalid
+		}
+	}
+	return legs, nil
+}
+
+// JSONLeaf is a string or number value in a JSON document.
+type JSONLeaf struct {
+	// Path is the path of the value with array indexes replaced by [*], e.g.
+	// `$.addresses[*].street`, so the values of array elements share a path.
+	Path string
+	// Value is the value of a string, or the JSON of a number.
+	Value string
+	legs  []jsonPathLeg
+}
+
+// JSONLeaves returns the string and number values of a JSON document.
+func JSONLeaves(doc string) ([]*JSONLeaf, error) {
+	if !json.Valid([]byte(doc)) {
+		return nil, errors.New("Invalid JSON document.")
+	}
+	scan := &jsonScanner{doc: doc}
+	scan.value(nil)
+	leaves := []*JSONLeaf{}
+	for _, l := range scan.leaves {
+		raw := doc[l.start:l.end]
+		leaf := &JSONLeaf{Path: jsonFormatPath(l.path), Value: raw, legs: l.path}
+		switch {
+		case raw[0] == '"':
+			json.Unmarshal([]byte(raw), &leaf.Value)
+		case raw == "true" || raw == "false" || raw == "null":
+			continue
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves, nil
+}
+
+// jsonPathIdentifier matches the keys which need no quotes in a path.
+var jsonPathIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsonFormatPath returns the path of the legs, with array indexes replaced by
+// [*].
+func jsonFormatPath(legs []jsonPathLeg) string {
+	b := &strings.Builder{}
+	b.WriteString("$")
+	for _, leg := range legs {
+		switch {
+		case leg.array:
+			b.WriteString("[*]")
+		case jsonPathIdentifier.MatchString(leg.key):
+			b.WriteString("." + leg.key)
+		default:
+			key, _ := json.Marshal(leg.key)
+			b.WriteString("." + string(key))
+		}
+	}
+	return b.String()
+}
+
+// jsonPathMatch returns whether the leaf at path is selected by pattern. The
+// leaves inside of a selected object or array are selected too.
+func jsonPathMatch(pattern, path []jsonPathLeg) bool {
+	if len(path) < len(pattern) {
+		return false
+	}
+	for i, leg := range pattern {
+		if leg.array != path[i].array {
+			return false
+		}
+		switch {
+		case leg.key == jsonPathWildcard:
+		case leg.array && leg.index != path[i].index:
+			return false
+		case !leg.array && leg.key != path[i].key:
+			return false
+		}
+	}
+	return true
+}
+
+// jsonLeaf is the position of a scalar value in a JSON document.
+type jsonLeaf struct {
+	path  []jsonPathLeg
+	start int
+	end   int
+}
+
+// jsonScanner finds the scalar values of a valid JSON document, with their
+// paths and byte offsets.
+type jsonScanner struct {
+	doc    string
+	i      int
+	leaves []jsonLeaf
+}
+
+// value scans the value starting at the current offset.
+func (s *jsonScanner) value(path []jsonPathLeg) {
+	s.space()
+	start := s.i
+	switch s.doc[s.i] {
+	case '{':
+		s.i++
+		for {
+			s.space()
+			if s.doc[s.i] == '}' {
+				break
+			}
+			keyStart := s.i
+			s.str()
+			var key string
+			json.Unmarshal([]byte(s.doc[keyStart:s.i]), &key)
+			s.space()
+			s.i++ // The colon.
+			s.value(append(path[:len(path):len(path)], jsonPathLeg{key: key}))
+			s.space()
+			if s.doc[s.i] == ',' {
+				s.i++
+			}
+		}
+		s.i++
+		return
+	case '[':
+		s.i++
+		for n := 0; ; n++ {
+			s.space()
+			if s.doc[s.i] == ']' {
+				break
+			}
+			s.value(append(path[:len(path):len(path)], jsonPathLeg{array: true, index: n}))
+			s.space()
+			if s.doc[s.i] == ',' {
+				s.i++
+			}
+		}
+		s.i++
+		return
+	case '"':
+		s.str()
+	default:
+		for s.i < len(s.doc) && !strings.ContainsRune(",]} \t\r\n", rune(s.doc[s.i])) {
+			s.i++
+		}
+	}
+	s.leaves = append(s.leaves, jsonLeaf{path: path, start: start, end: s.i})
+}
+
+// str skips the string starting at the current offset.
+func (s *jsonScanner) str() {
+	for s.i++; s.doc[s.i] != '"'; s.i++ {
+		if s.doc[s.i] == '\\' {
+			s.i++
+		}
+	}
+	s.i++
+}
+
+// space skips white space.
+func (s *jsonScanner) space() {
+	for s.i < len(s.doc) && strings.ContainsRune(" \t\r\n", rune(s.doc[s.i])) {
+		s.i++
+	}
+}
@@ -0,0 +1,89 @@
+package filters
+
+import "testing"
+
+func TestJSONFilter(t *testing.T) {
+	f := NewJSONFilter(map[string]Filter{
+		"empty":  NewEmptyFilter(),
+		"repeat": NewRepeatFilter(),
+		"noise":  NewNoiseFilter(),
+		"mask":   NewMaskFilter(),
+	})
+	tests := []struct {
+		args []string
+		in   string
+		ex   string
+	}{
+		{[]string{"$.phone", "empty"}, `{"name":"Sean","phone":"555-1234"}`, `{"name":"Sean","phone":""}`},
+		{[]string{"$.phone", "repeat", "X"}, `{"phone":"555-1234","age":42}`, `{"phone":"XXXXXXXX","age":42}`},
+		{[]string{"$.phone", "mask", "4"}, "{\n  \"z\": 1.50,\n  \"phone\" : \"555-1234\",\n  \"a\": [true, null]\n}", "{\n  \"z\": 1.50,\n  \"phone\" : \"***-1234\",\n  \"a\": [true, null]\n}"},
+		{[]string{`$."home phone"`, "empty"}, `{"home phone":"555","id":7}`, `{"home phone":"","id":7}`},
+		{[]string{"$.age", "empty"}, `{"age":42,"name":"<b>"}`, `{"age":0,"name":"<b>"}`},
+		{[]string{"$.age", "noise", "0"}, `{"age":42}`, `{"age":42}`},
+		{[]string{"$[*].n", "repeat", "x"}, `[{"n":"ab"},{"n":"\u00e9"}]`, `[{"n":"xx"},{"n":"x"}]`},
+		{[]string{"$.phones[*].number", "repeat", "9"}, `{"phones":[{"number":"123"},{"number":"45"}]}`, `{"phones":[{"number":"999"},{"number":"99"}]}`},
+		{[]string{"$.phones[1]", "empty"}, `{"phones":["123","45"]}`, `{"phones":["123",""]}`},
+		{[]string{"$.address", "empty"}, `{"address":{"city":"Berlin","zip":"10115"},"id":1}`, `{"address":{"city":"","zip":""},"id":1}`},
+		{[]string{"$.missing", "empty"}, `{"phone":"<555>"}`, `{"phone":"<555>"}`},
+		{[]string{"$.phone", "empty"}, ``, ``},
+	}
+	for _, test := range tests {
+		if err := f.ValidateArgs(test.args); err != nil {
+			t.Fatal(err)
+		}
+		ac := test.in
+		if err := f.Filter(&ac, &Context{DataType: "json"}, test.args); err != nil {
+			t.Fatal(err)
+		}
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
+
+func TestJSONFilterNumberType(t *testing.T) {
+	f := NewJSONFilter(map[string]Filter{
+		"repeat": NewRepeatFilter(),
+	})
+	v := `{"age":42}`
+	if err := f.Filter(&v, &Context{DataType: "json"}, []string{"$.age", "repeat", "X"}); err == nil {
+		t.Errorf(`Expected an error, got '%s'`, v)
+	}
+}
+
+func TestJSONFilterValidateArgs(t *testing.T) {
+	f := NewJSONFilter(map[string]Filter{
+		"empty": NewEmptyFilter(),
+	})
+	tests := [][]string{
+		{"$.phone"},
+		{"phone", "empty"},
+		{"$.phone[x]", "empty"},
+		{"$.phone", "missing"},
+		{"$.phone", "empty", "extra"},
+	}
+	for _, args := range tests {
+		if err := f.ValidateArgs(args); err == nil {
+			t.Errorf(`Expected an error for %v`, args)
+		}
+	}
+}
+
+func TestJSONLeaves(t *testing.T) {
+	leaves, err := JSONLeaves(`{"a":{"b c":"xé"},"d":[1,{"e":true}],"f":null}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := [][2]string{{`$.a."b c"`, "xé"}, {"$.d[*]", "1"}}
+	if len(leaves) != len(ex) {
+		t.Fatalf(`Expected %d leaves, got %d`, len(ex), len(leaves))
+	}
+	for i, leaf := range leaves {
+		if leaf.Path != ex[i][0] || leaf.Value != ex[i][1] {
+			t.Errorf(`Expected '%s' = '%s', got '%s' = '%s'`, ex[i][0], ex[i][1], leaf.Path, leaf.Value)
+		}
+	}
+	if _, err := JSONLeaves(`{"a":`); err == nil {
+		t.Errorf(`Expected an error for an invalid document`)
+	}
+}
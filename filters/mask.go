@@ -0,0 +1,55 @@
+package filters
+
+import (
+	"errors"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaskFilter replaces the letters and digits of column values with a mask
+// character, except for the given number of trailing characters. Separators
+// are kept, so "555-1234" masked keeping 4 becomes "***-1234".
+type MaskFilter struct {
+}
+
+// NewMaskFilter returns a new *MaskFilter instance.
+func NewMaskFilter() *MaskFilter {
+	return &MaskFilter{}
+}
+
+// Filter...
+func (f *MaskFilter) Filter(value *string, ctx *Context, args []string) error {
+	keep, _ := strconv.Atoi(args[0])
+	mask := '*'
+	if len(args) > 1 {
+		mask, _ = utf8.DecodeRuneInString(args[1])
+	}
+	runes := []rune(*value)
+	for i := 0; i < len(runes)-keep; i++ {
+		if unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) {
+			runes[i] = mask
+		}
+	}
+	*value = string(runes)
+	return nil
+}
+
+// ValidateArgs...
+func (f *MaskFilter) ValidateArgs(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New(`Filter "mask" expects 1 or 2 arguments.`)
+	}
+	if keep, err := strconv.Atoi(args[0]); err != nil || keep < 0 {
+		return errors.New(`Filter "mask" expects the number of characters to keep to be a positive integer.`)
+	}
+	if len(args) == 2 && utf8.RuneCountInString(args[1]) != 1 {
+		return errors.New(`Filter "mask" expects the mask to be a single character.`)
+	}
+	return nil
+}
+
+// Usage...
+func (f *MaskFilter) Usage() string {
+	return `mask table.column <keep> [<char>]`
+}
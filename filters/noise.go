@@ -0,0 +1,66 @@
+package filters
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// NoiseFilter adds random noise to numeric column values. The noise is either
+// an amount added or subtracted from the value, or when the amount ends with
+// a percent sign, a percentage the value is multiplied by.
+type NoiseFilter struct {
+	rand *rand.Rand
+}
+
+// NewNoiseFilter returns a new *NoiseFilter instance.
+func NewNoiseFilter() *NoiseFilter {
+	return &NoiseFilter{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Filter...
+func (f *NoiseFilter) Filter(value *string, ctx *Context, args []string) error {
+	if *value == "" {
+		return nil
+	}
+	v, err := numericParse("noise", *value, ctx)
+	if err != nil {
+		return err
+	}
+	amount, percent := noiseParseAmount(args[0])
+	r := new(big.Rat).SetFloat64(f.rand.Float64()*2 - 1)
+	delta := new(big.Rat).Mul(r, amount)
+	if percent {
+		delta.Mul(delta, v).Quo(delta, big.NewRat(100, 1))
+	}
+	v.Add(v, delta)
+	*value, err = numericFormat("noise", v, ctx)
+	return err
+}
+
+// ValidateArgs...
+func (f *NoiseFilter) ValidateArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New(`Filter "noise" expects exactly 1 argument.`)
+	}
+	if _, ok := new(big.Rat).SetString(strings.TrimSuffix(args[0], "%")); !ok {
+		return errors.New(`Filter "noise" expects the amount to be a number or a percentage.`)
+	}
+	return nil
+}
+
+// Usage...
+func (f *NoiseFilter) Usage() string {
+	return `noise table.column <amount|percent%>`
+}
+
+// noiseParseAmount...
+func noiseParseAmount(arg string) (amount *big.Rat, percent bool) {
+	percent = strings.HasSuffix(arg, "%")
+	amount, _ = new(big.Rat).SetString(strings.TrimSuffix(arg, "%"))
+	return
+}
@@ -0,0 +1,117 @@
+package filters
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// numericIntBits stores the sizes in bits of the integer types.
+var numericIntBits = map[string]uint{
+	"tinyint":   8,
+	"smallint":  16,
+	"mediumint": 24,
+	"int":       32,
+	"integer":   32,
+	"bigint":    64,
+}
+
+// numericParse parses the value being filtered as a number. Values are exact
+// rationals so bigint and decimal values keep every digit.
+func numericParse(name, value string, ctx *Context) (*big.Rat, error) {
+	v, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf(`Filter "%s" cannot parse "%s" in "%s.%s" as a number.`, name, value, ctx.TableName, ctx.ColumnName)
+	}
+	return v, nil
+}
+
+// numericFormat rounds and clamps v so it fits the column described by ctx,
+// and returns it formatted as a column value.
+func numericFormat(name string, v *big.Rat, ctx *Context) (string, error) {
+	unsigned := strings.Contains(ctx.ColumnType, "unsigned")
+	if bits, ok := numericIntBits[ctx.DataType]; ok {
+		min, max := numericIntBounds(bits, unsigned)
+		n := numericRoundInt(v)
+		if n.Cmp(min) < 0 {
+			n = min
+		}
+		if n.Cmp(max) > 0 {
+			n = max
+		}
+		return n.String(), nil
+	}
+
+	switch ctx.DataType {
+	case "decimal", "numeric":
+		scale := int(ctx.Scale)
+		max := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(ctx.Precision), nil))
+		max.Sub(max, big.NewRat(1, 1))
+		max.Quo(max, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)))
+		min := new(big.Rat).Neg(max)
+		if unsigned {
+			min = new(big.Rat)
+		}
+		v = numericClamp(numericRound(v, scale), min, max)
+		return v.FloatString(scale), nil
+	case "float", "double", "real":
+		if unsigned && v.Sign() < 0 {
+			v = new(big.Rat)
+		}
+		if ctx.Scale > 0 {
+			v = numericRound(v, int(ctx.Scale))
+		}
+		bits := 64
+		f, _ := v.Float64()
+		if ctx.DataType == "float" {
+			bits = 32
+			f32, _ := v.Float32()
+			f = float64(f32)
+		}
+		return strconv.FormatFloat(f, 'f', -1, bits), nil
+	}
+	return "", fmt.Errorf(`Filter "%s" cannot be used on %s column "%s.%s".`, name, ctx.DataType, ctx.TableName, ctx.ColumnName)
+}
+
+// numericIntBounds returns the smallest and largest values of an integer type
+// of the given size.
+func numericIntBounds(bits uint, unsigned bool) (min, max *big.Int) {
+	one := big.NewInt(1)
+	if unsigned {
+		max = new(big.Int).Lsh(one, bits)
+		return new(big.Int), max.Sub(max, one)
+	}
+	max = new(big.Int).Lsh(one, bits-1)
+	min = new(big.Int).Neg(max)
+	return min, max.Sub(max, one)
+}
+
+// numericRoundInt rounds v to the nearest integer, rounding halves away from
+// zero the way the server does.
+func numericRoundInt(v *big.Rat) *big.Int {
+	q, r := new(big.Int).QuoRem(v.Num(), v.Denom(), new(big.Int))
+	r.Abs(r).Lsh(r, 1)
+	if r.Cmp(v.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(v.Sign())))
+	}
+	return q
+}
+
+// numericRound rounds v to the given number of decimal places.
+func numericRound(v *big.Rat, places int) *big.Rat {
+	p := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil))
+	n := numericRoundInt(new(big.Rat).Mul(v, p))
+	return new(big.Rat).Quo(new(big.Rat).SetInt(n), p)
+}
+
+// numericClamp...
+func numericClamp(v, min, max *big.Rat) *big.Rat {
+	if v.Cmp(min) < 0 {
+		return min
+	}
+	if v.Cmp(max) > 0 {
+		return max
+	}
+	return v
+}
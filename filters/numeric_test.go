@@ -0,0 +1,132 @@
+package filters
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestNumericFormat(t *testing.T) {
+	tests := []struct {
+		ctx *Context
+		in  string
+		ex  string
+	}{
+		{&Context{DataType: "int", ColumnType: "int(11)"}, "41.6", "42"},
+		{&Context{DataType: "int", ColumnType: "int(11)"}, "-41.5", "-42"},
+		{&Context{DataType: "int", ColumnType: "int(10) unsigned"}, "-5", "0"},
+		{&Context{DataType: "tinyint", ColumnType: "tinyint(4)"}, "300", "127"},
+		{&Context{DataType: "tinyint", ColumnType: "tinyint(4)"}, "-300", "-128"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "1e30", "18446744073709551615"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "18446744073709551615", "18446744073709551615"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "18446744073709551614", "18446744073709551614"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20)"}, "1e30", "9223372036854775807"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20)"}, "9223372036854775807", "9223372036854775807"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20)"}, "-9223372036854775808", "-9223372036854775808"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20)"}, "9007199254740993", "9007199254740993"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(10,2)", Precision: 10, Scale: 2}, "1234.5678", "1234.57"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(5,2)", Precision: 5, Scale: 2}, "123456", "999.99"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(5,2) unsigned", Precision: 5, Scale: 2}, "-1", "0.00"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(30,10)", Precision: 30, Scale: 10}, "12345678901234567890.12345678905", "12345678901234567890.1234567891"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(30,10)", Precision: 30, Scale: 10}, "1e25", "99999999999999999999.9999999999"},
+		{&Context{DataType: "float", ColumnType: "float(7,3)", Precision: 7, Scale: 3}, "1.23456", "1.235"},
+		{&Context{DataType: "double", ColumnType: "double"}, "1.5", "1.5"},
+	}
+	for _, test := range tests {
+		v, err := numericParse("test", test.in, test.ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ac, err := numericFormat("test", v, test.ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+	if _, err := numericFormat("test", big.NewRat(1, 1), &Context{DataType: "varchar"}); err == nil {
+		t.Error("Expected an error for a varchar column")
+	}
+}
+
+func TestBucketFilter(t *testing.T) {
+	f := NewBucketFilter()
+	ctx := &Context{DataType: "decimal", ColumnType: "decimal(10,2)", Precision: 10, Scale: 2}
+	tests := map[string]string{
+		"52340.17": "52000.00",
+		"52600.00": "53000.00",
+		"":         "",
+	}
+	for in, ex := range tests {
+		ac := in
+		if err := f.Filter(&ac, ctx, []string{"1000"}); err != nil {
+			t.Fatal(err)
+		}
+		if ac != ex {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestBucketFilterPrecision(t *testing.T) {
+	f := NewBucketFilter()
+	tests := []struct {
+		ctx  *Context
+		in   string
+		size string
+		ex   string
+	}{
+		{&Context{DataType: "double", ColumnType: "double"}, "0.31", "0.1", "0.3"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20)"}, "9223372036854775807", "10", "9223372036854775807"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "18446744073709551611", "10", "18446744073709551610"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(30,10)", Precision: 30, Scale: 10}, "12345678901234567890.1234567890", "0.001", "12345678901234567890.1230000000"},
+	}
+	for _, test := range tests {
+		ac := test.in
+		if err := f.Filter(&ac, test.ctx, []string{test.size}); err != nil {
+			t.Fatal(err)
+		}
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
+
+func TestClampFilter(t *testing.T) {
+	f := NewClampFilter()
+	tests := []struct {
+		ctx  *Context
+		in   string
+		args []string
+		ex   string
+	}{
+		{&Context{DataType: "bigint", ColumnType: "bigint(20)"}, "9007199254740993", []string{"0", "9223372036854775807"}, "9007199254740993"},
+		{&Context{DataType: "bigint", ColumnType: "bigint(20) unsigned"}, "18446744073709551615", []string{"0", "18446744073709551614"}, "18446744073709551614"},
+		{&Context{DataType: "decimal", ColumnType: "decimal(30,10)", Precision: 30, Scale: 10}, "12345678901234567890.1234567890", []string{"0", "1e19"}, "10000000000000000000.0000000000"},
+		{&Context{DataType: "int", ColumnType: "int(11)"}, "-7", []string{"-5", "5"}, "-5"},
+	}
+	for _, test := range tests {
+		ac := test.in
+		if err := f.Filter(&ac, test.ctx, test.args); err != nil {
+			t.Fatal(err)
+		}
+		if ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
+
+func TestNoiseFilter(t *testing.T) {
+	f := NewNoiseFilter()
+	ctx := &Context{DataType: "int", ColumnType: "int(10) unsigned"}
+	for i := 0; i < 100; i++ {
+		v := "100"
+		if err := f.Filter(&v, ctx, []string{"10%"}); err != nil {
+			t.Fatal(err)
+		}
+		if n, _ := strconv.Atoi(v); n < 90 || n > 110 {
+			t.Errorf(`Expected '%s' to be within 10%% of 100`, v)
+		}
+	}
+}
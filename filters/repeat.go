@@ -15,8 +15,8 @@ func NewRepeatFilter() *RepeatFilter {
 }
 
 // Filter...
-func (f *RepeatFilter) Filter(value *string, dataType string, maxLength int64, args []string) error {
-	*value = strings.Repeat(args[0], int(maxLength))
+func (f *RepeatFilter) Filter(value *string, ctx *Context, args []string) error {
+	*value = strings.Repeat(args[0], int(ctx.MaxLength))
 	return nil
 }
 
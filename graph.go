@@ -0,0 +1,273 @@
+package dbsample
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// mermaidNameRegexp matches the characters which are not allowed in Mermaid
+// entity names.
+var mermaidNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// GraphEdge is a foreign key between two tables in an exported graph.
+type GraphEdge struct {
+	*Constraint
+	// Child is the name of the table which holds the foreign key column.
+	Child string
+	// SelfReference is whether the child and parent are the same table.
+	SelfReference bool
+	// Cycle is whether the edge is part of a circular dependency between
+	// tables, which dbsample cannot sample.
+	Cycle bool
+}
+
+// Graph is the tables of a database and the foreign keys between them, as used
+// to decide the order tables are sampled in.
+type Graph struct {
+	Name   string
+	Tables TableGraph
+	Edges  []*GraphEdge
+	// Skipped are the names of the tables which sampling is predicted to skip,
+	// because they reference a table estimated to be empty or a skipped table.
+	Skipped map[string]bool
+}
+
+// NewGraph returns a new *Graph instance for the tables, which do not need to
+// be sorted by their constraints.
+func NewGraph(name string, tables TableGraph) *Graph {
+	g := &Graph{
+		Name:    name,
+		Tables:  append(TableGraph{}, tables...),
+		Edges:   []*GraphEdge{},
+		Skipped: graphSkippedTables(tables),
+	}
+	sort.Slice(g.Tables, func(i, j int) bool {
+		return g.Tables[i].Name < g.Tables[j].Name
+	})
+
+	cycles := graphCycles(tables)
+	for _, table := range g.Tables {
+		for _, fk := range table.Constraints {
+			g.Edges = append(g.Edges, &GraphEdge{
+				Constraint: fk,
+				Child:      table.Name,
+				Cycle:      cycles[table.Name] != 0 && cycles[table.Name] == cycles[fk.TableName],
+			})
+		}
+		for _, fk := range table.SelfReferences {
+			g.Edges = append(g.Edges, &GraphEdge{
+				Constraint:    fk,
+				Child:         table.Name,
+				SelfReference: true,
+			})
+		}
+	}
+	return g
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Edges point from the
+// referencing table to the referenced table. Constraints given with
+// --constraint are dashed, self references are blue, circular dependencies are
+// red, and skipped tables are grey.
+func (g *Graph) WriteDOT(w io.Writer) {
+	fmt.Fprintf(w, "digraph %s {\n", graphDOTQuote(g.Name))
+	fmt.Fprint(w, "  rankdir=LR;\n")
+	fmt.Fprint(w, "  node [shape=box];\n\n")
+	for _, table := range g.Tables {
+		if g.Skipped[table.Name] {
+			fmt.Fprintf(w, "  %s [label=%s, style=dashed, color=gray, fontcolor=gray];\n", graphDOTQuote(table.Name), graphDOTQuote(table.Name+"\n(skipped)"))
+		} else {
+			fmt.Fprintf(w, "  %s;\n", graphDOTQuote(table.Name))
+		}
+	}
+	if len(g.Edges) > 0 {
+		fmt.Fprint(w, "\n")
+	}
+	for _, edge := range g.Edges {
+		attrs := []string{"label=" + graphDOTQuote(edge.ReferencedColumnName+" -> "+edge.ColumnName)}
+		if edge.UserDefined {
+			attrs = append(attrs, "style=dashed")
+		}
+		switch {
+		case edge.Cycle:
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		case edge.SelfReference:
+			attrs = append(attrs, "color=blue", "fontcolor=blue")
+		}
+		fmt.Fprintf(w, "  %s -> %s [%s];\n", graphDOTQuote(edge.Child), graphDOTQuote(edge.TableName), strings.Join(attrs, ", "))
+	}
+	fmt.Fprint(w, "}\n")
+}
+
+// WriteMermaid writes the graph as a Mermaid ER diagram. Each foreign key is a
+// relationship from the referenced table to the referencing table, labelled
+// with the columns and marked as (constraint), (self) or (cycle). Mermaid does
+// not style single entities, so skipped tables are listed in comments and
+// marked with a SKIPPED attribute.
+func (g *Graph) WriteMermaid(w io.Writer) {
+	fmt.Fprint(w, "erDiagram\n")
+	for _, table := range g.Tables {
+		if g.Skipped[table.Name] {
+			fmt.Fprintf(w, "  %%%% %s would be skipped.\n", MySQL5Comment(MySQL5Backtick(table.Name)))
+		}
+	}
+	for _, table := range g.Tables {
+		fks := map[string]bool{}
+		for _, fk := range table.Constraints {
+			fks[fk.ReferencedColumnName] = true
+		}
+		for _, fk := range table.SelfReferences {
+			fks[fk.ReferencedColumnName] = true
+		}
+		fmt.Fprintf(w, "  %s {\n", graphMermaidName(table.Name))
+		for _, col := range table.Columns.Ordered() {
+			key := ""
+			if fks[col.Name] {
+				key = " FK"
+			}
+			fmt.Fprintf(w, "    %s %s%s\n", graphMermaidName(col.DataType), graphMermaidName(col.Name), key)
+		}
+		if g.Skipped[table.Name] {
+			fmt.Fprint(w, "    sampling SKIPPED \"references a skipped or empty table\"\n")
+		}
+		fmt.Fprint(w, "  }\n")
+	}
+	for _, edge := range g.Edges {
+		label := fmt.Sprintf("%s.%s -> %s.%s", edge.Child, edge.ReferencedColumnName, edge.TableName, edge.ColumnName)
+		marks := []string{}
+		if edge.UserDefined {
+			marks = append(marks, "constraint")
+		}
+		if edge.SelfReference {
+			marks = append(marks, "self")
+		}
+		if edge.Cycle {
+			marks = append(marks, "cycle")
+		}
+		if len(marks) > 0 {
+			label += " (" + strings.Join(marks, ", ") + ")"
+		}
+		fmt.Fprintf(w, "  %s ||--o{ %s : \"%s\"\n", graphMermaidName(edge.TableName), graphMermaidName(edge.Child), strings.Replace(label, `"`, "'", -1))
+	}
+}
+
+// graphDOTQuote returns the string as a quoted DOT identifier.
+func graphDOTQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return `"` + s + `"`
+}
+
+// graphMermaidName returns the name with the characters Mermaid does not allow
+// replaced by underscores.
+func graphMermaidName(s string) string {
+	return mermaidNameRegexp.ReplaceAllString(s, "_")
+}
+
+// graphSkippedTables returns the names of the tables which reference a table
+// estimated to be empty, or a table which would itself be skipped. Unlike
+// resolveTableSkipped the tables do not need to be sorted, so tables in a
+// circular dependency are handled.
+func graphSkippedTables(tables TableGraph) map[string]bool {
+	estimates := map[string]int64{}
+	for _, table := range tables {
+		estimates[table.Name] = table.EstimatedRows
+	}
+	skipped := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, table := range tables {
+			if skipped[table.Name] {
+				continue
+			}
+			for _, fk := range table.Constraints {
+				if est, ok := estimates[fk.TableName]; (ok && est == 0) || skipped[fk.TableName] {
+					skipped[table.Name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return skipped
+}
+
+// graphCycles returns the tables which are part of a circular dependency,
+// mapped to a number identifying the cycle. Tables which are not part of a
+// cycle are not included. Cycles are found with Tarjan's strongly connected
+// components algorithm.
+func graphCycles(tables TableGraph) map[string]int {
+	parents := map[string][]string{}
+	for _, table := range tables {
+		for _, fk := range table.Constraints {
+			parents[table.Name] = append(parents[table.Name], fk.TableName)
+		}
+	}
+
+	index := 0
+	indexes := map[string]int{}
+	lowlinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	cycles := map[string]int{}
+	cycle := 0
+
+	var connect func(name string)
+	connect = func(name string) {
+		indexes[name] = index
+		lowlinks[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, parent := range parents[name] {
+			if _, ok := indexes[parent]; !ok {
+				connect(parent)
+				if lowlinks[parent] < lowlinks[name] {
+					lowlinks[name] = lowlinks[parent]
+				}
+			} else if onStack[parent] && indexes[parent] < lowlinks[name] {
+				lowlinks[name] = indexes[parent]
+			}
+		}
+		if lowlinks[name] != indexes[name] {
+			return
+		}
+		component := []string{}
+		for {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[n] = false
+			component = append(component, n)
+			if n == name {
+				break
+			}
+		}
+		if len(component) > 1 {
+			cycle++
+			for _, n := range component {
+				cycles[n] = cycle
+			}
+		}
+	}
+
+	names := []string{}
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := indexes[name]; !ok {
+			connect(name)
+		}
+	}
+	return cycles
+}
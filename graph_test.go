@@ -0,0 +1,86 @@
+package dbsample
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func testGraphTables() TableGraph {
+	users := NewTable()
+	users.Name = "users"
+	users.EstimatedRows = 10
+	users.Columns = ColumnMap{"id": &Column{Name: "id", DataType: "int", OrdinalPosition: 1}}
+	categories := NewTable()
+	categories.Name = "categories"
+	categories.EstimatedRows = 5
+	categories.SelfReferences = []*Constraint{{TableName: "categories", ColumnName: "id", ReferencedColumnName: "parent_id"}}
+	posts := NewTable()
+	posts.Name = "posts"
+	posts.EstimatedRows = 20
+	posts.Constraints = []*Constraint{
+		{TableName: "users", ColumnName: "id", ReferencedColumnName: "user_id"},
+		{TableName: "categories", ColumnName: "id", ReferencedColumnName: "cat_id", UserDefined: true},
+	}
+	tags := NewTable()
+	tags.Name = "tags"
+	comments := NewTable()
+	comments.Name = "comments"
+	comments.EstimatedRows = 3
+	comments.Constraints = []*Constraint{{TableName: "tags", ColumnName: "id", ReferencedColumnName: "tag_id"}}
+	a := NewTable()
+	a.Name = "a"
+	a.EstimatedRows = 1
+	a.Constraints = []*Constraint{{TableName: "b", ColumnName: "id", ReferencedColumnName: "b_id"}}
+	b := NewTable()
+	b.Name = "b"
+	b.EstimatedRows = 1
+	b.Constraints = []*Constraint{{TableName: "a", ColumnName: "id", ReferencedColumnName: "a_id"}}
+	return TableGraph{posts, users, categories, tags, comments, a, b}
+}
+
+func TestGraphWriteDOT(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewGraph("blog", testGraphTables()).WriteDOT(buf)
+	ac := buf.String()
+	for _, ex := range []string{
+		`digraph "blog" {`,
+		`  "posts" -> "users" [label="user_id -> id"];`,
+		`  "posts" -> "categories" [label="cat_id -> id", style=dashed];`,
+		`  "categories" -> "categories" [label="parent_id -> id", color=blue, fontcolor=blue];`,
+		`  "a" -> "b" [label="b_id -> id", color=red, fontcolor=red];`,
+		`  "b" -> "a" [label="a_id -> id", color=red, fontcolor=red];`,
+		`  "comments" [label="comments\n(skipped)", style=dashed, color=gray, fontcolor=gray];`,
+		`  "tags";`,
+	} {
+		if !strings.Contains(ac, ex) {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestGraphWriteMermaid(t *testing.T) {
+	buf := &bytes.Buffer{}
+	NewGraph("blog", testGraphTables()).WriteMermaid(buf)
+	ac := buf.String()
+	for _, ex := range []string{
+		"erDiagram\n",
+		"  %% `comments` would be skipped.\n",
+		"  users {\n    int id\n  }\n",
+		`  users ||--o{ posts : "posts.user_id -> users.id"`,
+		`  categories ||--o{ posts : "posts.cat_id -> categories.id (constraint)"`,
+		`  categories ||--o{ categories : "categories.parent_id -> categories.id (self)"`,
+		`  b ||--o{ a : "a.b_id -> b.id (cycle)"`,
+	} {
+		if !strings.Contains(ac, ex) {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestGraphCycles(t *testing.T) {
+	cycles := graphCycles(testGraphTables())
+	if len(cycles) != 2 || cycles["a"] == 0 || cycles["a"] != cycles["b"] {
+		t.Errorf(`Expected '%s', got '%v'`, "a and b in one cycle", cycles)
+	}
+}
@@ -0,0 +1,60 @@
+package dbsample
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mysql5QuotedEnd returns the index after the quoted string or identifier
+// starting at i. Backslashes escape characters in strings but not in
+// identifiers, and a doubled quote is read as two adjacent quoted strings.
+func mysql5QuotedEnd(sql string, i int) (int, error) {
+	quote := sql[i]
+	for j := i + 1; j < len(sql); j++ {
+		switch sql[j] {
+		case '\\':
+			if quote != '`' {
+				j++
+			}
+		case quote:
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("Unterminated %c quoted value.", quote)
+}
+
+// parseMySQL5Identifier parses a backtick quoted identifier starting at i, and
+// returns the identifier and the index after it.
+func parseMySQL5Identifier(sql string, i int) (string, int, error) {
+	if i >= len(sql) || sql[i] != '`' {
+		return "", 0, fmt.Errorf("Expected a quoted identifier at %q.", mysql5Prefix(sql[i:], 20))
+	}
+	end, err := mysql5QuotedEnd(sql, i)
+	if err != nil {
+		return "", 0, err
+	}
+	for end < len(sql) && sql[end] == '`' {
+		if end, err = mysql5QuotedEnd(sql, end); err != nil {
+			return "", 0, err
+		}
+	}
+	name := sql[i+1 : end-1]
+	return strings.Replace(name, "``", "`", -1), end, nil
+}
+
+// skipMySQL5Space returns the index of the first character at or after i which
+// is not white space.
+func skipMySQL5Space(sql string, i int) int {
+	for i < len(sql) && strings.IndexByte(" \t\r\n", sql[i]) != -1 {
+		i++
+	}
+	return i
+}
+
+// mysql5Prefix returns up to n bytes from the start of s.
+func mysql5Prefix(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,23 @@
+package dbsample
+
+import (
+	"fmt"
+	"io"
+)
+
+// Loader applies a sample directly to a target database.
+type Loader interface {
+	Load(w io.Writer, db Database) error
+}
+
+// NewLoader returns a Loader instance which loads into the target server.
+func NewLoader(target *Server) (Loader, error) {
+	switch target.conn.Driver {
+	case DriverMySQL:
+		switch target.major {
+		case "5":
+			return NewMySQL5Loader(target.args, target), nil
+		}
+	}
+	return nil, fmt.Errorf("Loader not available for %s %s", target.conn.Driver, target.major)
+}
@@ -0,0 +1,359 @@
+package dbsample
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// mysql5LoadBatchSize is the number of rows inserted by each statement.
+const mysql5LoadBatchSize = 100
+
+// mysql5LoadMaxPlaceholders is the number of placeholders the server allows in
+// a prepared statement.
+const mysql5LoadMaxPlaceholders = 65535
+
+// mysql5LoadValueLength is the number of bytes of each value shown when a row
+// fails to load.
+const mysql5LoadValueLength = 32
+
+// MySQL5Loader loads the tables, rows, triggers, views and routines of a
+// sample into the database of the target server. Foreign key checks are
+// disabled during the load, and the rows of each table are inserted in a
+// single transaction, so a failed table leaves no partial rows behind.
+type MySQL5Loader struct {
+	args   *DumpArgs
+	target *Server
+}
+
+// NewMySQL5Loader returns a new *MySQL5Loader instance.
+func NewMySQL5Loader(args *DumpArgs, target *Server) *MySQL5Loader {
+	return &MySQL5Loader{
+		args:   args,
+		target: target,
+	}
+}
+
+// Load writes the number of rows loaded into each table to w.
+func (l *MySQL5Loader) Load(w io.Writer, db Database) error {
+	if err := l.checkTarget(db); err != nil {
+		return err
+	}
+	tables, err := db.Tables()
+	if err != nil {
+		return err
+	}
+	views, err := db.Views()
+	if err != nil {
+		return err
+	}
+	routines, err := db.Routines()
+	if err != nil {
+		return err
+	}
+
+	// Session variables only apply to the connection which sets them, so the
+	// whole load uses a single connection.
+	ctx := context.Background()
+	conn, err := l.target.session()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for _, sql := range []string{
+		"SET FOREIGN_KEY_CHECKS = 0",
+		"SET UNIQUE_CHECKS = 0",
+		"SET SQL_MODE = 'NO_AUTO_VALUE_ON_ZERO'",
+		"SET TIME_ZONE = '+00:00'",
+	} {
+		if _, err = conn.ExecContext(ctx, sql); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range tables {
+		if err = l.createTable(ctx, conn, table); err != nil {
+			return err
+		}
+		if err = l.insertRows(ctx, conn, table); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Loaded %d rows into %s.\n", len(table.Rows), MySQL5Backtick(table.Name))
+	}
+	for _, table := range tables {
+		for _, trigger := range table.Triggers {
+			if err = l.createTrigger(ctx, conn, trigger); err != nil {
+				return err
+			}
+		}
+	}
+	for _, view := range views {
+		if err = l.createView(ctx, conn, view); err != nil {
+			return err
+		}
+	}
+	for _, routine := range routines {
+		if err = l.createRoutine(ctx, conn, routine); err != nil {
+			return err
+		}
+	}
+
+	for _, sql := range []string{
+		"SET FOREIGN_KEY_CHECKS = 1",
+		"SET UNIQUE_CHECKS = 1",
+	} {
+		if _, err = conn.ExecContext(ctx, sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTable...
+func (l *MySQL5Loader) createTable(ctx context.Context, conn *gosql.Conn, table *Table) error {
+	if !l.args.SkipAddDropTable {
+		if err := l.exec(ctx, conn, "DROP TABLE IF EXISTS "+MySQL5Backtick(table.Name)); err != nil {
+			return err
+		}
+	}
+	if err := l.exec(ctx, conn, table.CreateSQL); err != nil {
+		return fmt.Errorf("Creating table %s failed: %s", MySQL5Backtick(table.Name), err)
+	}
+	return nil
+}
+
+// checkTarget returns an error when the target database is the source
+// database, which the load would drop the tables of. The servers are compared
+// by the host name and port they report, so different addresses of the same
+// server are caught too.
+func (l *MySQL5Loader) checkTarget(db Database) error {
+	source, err := mysql5ServerIdentity(db.Server())
+	if err != nil {
+		return err
+	}
+	target, err := mysql5ServerIdentity(l.target)
+	if err != nil {
+		return err
+	}
+	if source == target && strings.EqualFold(db.Name(), l.target.conn.Name) {
+		return fmt.Errorf("The target database %s is the source database, refusing to load.", MySQL5Backtick(db.Name()))
+	}
+	return nil
+}
+
+// mysql5ServerIdentity returns the host name and port the server reports.
+func mysql5ServerIdentity(s *Server) (string, error) {
+	rows, err := s.query("SELECT @@hostname, @@port")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = fmt.Errorf("SELECT @@hostname, @@port returned 0 rows")
+		}
+		return "", err
+	}
+	var host, port string
+	if err = rows.Scan(&host, &port); err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, port), rows.Err()
+}
+
+// insertRows inserts the rows in batches. When a batch fails the rows in the
+// batch are inserted one at a time to find the row which caused the error.
+func (l *MySQL5Loader) insertRows(ctx context.Context, conn *gosql.Conn, table *Table) (err error) {
+	if len(table.Rows) == 0 {
+		return nil
+	}
+	cols := []string{}
+	types := []*Column{}
+	for _, field := range table.Rows[0] {
+		cols = append(cols, field.Column)
+		types = append(types, table.Columns[field.Column])
+	}
+
+	var tx *gosql.Tx
+	if tx, err = conn.BeginTx(ctx, nil); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	stmts := map[int]*gosql.Stmt{}
+	defer func() {
+		for _, stmt := range stmts {
+			stmt.Close()
+		}
+	}()
+	size := mysql5LoadBatchRows(len(cols))
+	for start := 0; start < len(table.Rows); start += size {
+		end := start + size
+		if end > len(table.Rows) {
+			end = len(table.Rows)
+		}
+		batch := table.Rows[start:end]
+		stmt, ok := stmts[len(batch)]
+		if !ok {
+			if stmt, err = tx.PrepareContext(ctx, l.insertSQL(table.Name, cols, len(batch))); err != nil {
+				return
+			}
+			stmts[len(batch)] = stmt
+		}
+		args := []interface{}{}
+		for _, row := range batch {
+			args = append(args, l.insertArgs(row, types)...)
+		}
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			err = l.findFailedRow(ctx, tx, table, cols, types, start, end, err)
+			return
+		}
+	}
+	return tx.Commit()
+}
+
+// mysql5LoadBatchRows returns the number of rows inserted by each statement
+// into a table with the given number of columns, keeping the placeholders of
+// the statement within the limit of the server.
+func mysql5LoadBatchRows(cols int) int {
+	if cols == 0 {
+		return mysql5LoadBatchSize
+	}
+	if n := mysql5LoadMaxPlaceholders / cols; n < mysql5LoadBatchSize {
+		if n < 1 {
+			return 1
+		}
+		return n
+	}
+	return mysql5LoadBatchSize
+}
+
+// findFailedRow inserts the rows of a failed batch one at a time, and returns
+// an error describing the first row which fails.
+func (l *MySQL5Loader) findFailedRow(ctx context.Context, tx *gosql.Tx, table *Table, cols []string, types []*Column, start, end int, batchErr error) error {
+	sql := l.insertSQL(table.Name, cols, 1)
+	for i := start; i < end; i++ {
+		row := table.Rows[i]
+		if _, err := tx.ExecContext(ctx, sql, l.insertArgs(row, types)...); err != nil {
+			return fmt.Errorf("Loading %s failed on row %d (%s): %s", MySQL5Backtick(table.Name), i+1, l.describeRow(row), err)
+		}
+	}
+	return fmt.Errorf("Loading %s failed on rows %d to %d: %s", MySQL5Backtick(table.Name), start+1, end, batchErr)
+}
+
+// createTrigger...
+func (l *MySQL5Loader) createTrigger(ctx context.Context, conn *gosql.Conn, t *Trigger) error {
+	if err := l.exec(ctx, conn, "DROP TRIGGER IF EXISTS "+MySQL5Backtick(t.Name)); err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(
+		"CREATE TRIGGER %s %s %s ON %s FOR EACH %s %s",
+		MySQL5Backtick(t.Name),
+		t.ActionTiming,
+		t.EventManipulation,
+		MySQL5Backtick(t.EventObjectTable),
+		t.ActionOrientation,
+		t.CreateSQL,
+	)
+	if err := l.exec(ctx, conn, sql); err != nil {
+		return fmt.Errorf("Creating trigger %s failed: %s", MySQL5Backtick(t.Name), err)
+	}
+	return nil
+}
+
+// createView...
+func (l *MySQL5Loader) createView(ctx context.Context, conn *gosql.Conn, v *View) error {
+	sql := fmt.Sprintf("CREATE OR REPLACE SQL SECURITY %s %s", v.SecurityType, v.CreateSQL)
+	if err := l.exec(ctx, conn, sql); err != nil {
+		return fmt.Errorf("Creating view %s failed: %s", MySQL5Backtick(v.Name), err)
+	}
+	return nil
+}
+
+// createRoutine...
+func (l *MySQL5Loader) createRoutine(ctx context.Context, conn *gosql.Conn, r *Routine) error {
+	if err := l.exec(ctx, conn, fmt.Sprintf("DROP %s IF EXISTS %s", r.Type, MySQL5Backtick(r.Name))); err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("CREATE %s %s(%s)", r.Type, MySQL5Backtick(r.Name), r.ParamList)
+	if r.Type == "FUNCTION" {
+		sql += " RETURNS " + r.Returns
+	}
+	if r.IsDeterministic == "YES" {
+		sql += " DETERMINISTIC"
+	}
+	sql += "\n" + r.CreateSQL
+	if err := l.exec(ctx, conn, sql); err != nil {
+		return fmt.Errorf("Creating routine %s failed: %s", MySQL5Backtick(r.Name), err)
+	}
+	return nil
+}
+
+// exec...
+func (l *MySQL5Loader) exec(ctx context.Context, conn *gosql.Conn, sql string) error {
+	_, err := conn.ExecContext(ctx, sql)
+	return err
+}
+
+// insertSQL returns an INSERT statement with placeholders for the given
+// number of rows.
+func (l *MySQL5Loader) insertSQL(tableName string, cols []string, rows int) string {
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") + ")"
+	values := make([]string, rows)
+	for i := range values {
+		values[i] = placeholders
+	}
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES %s",
+		MySQL5Backtick(tableName),
+		MySQL5JoinColumns(cols),
+		strings.Join(values, ", "),
+	)
+}
+
+// insertArgs returns the row values as statement arguments. Binary values are
+// passed as bytes so they are not converted by the connection character set.
+func (l *MySQL5Loader) insertArgs(row Row, types []*Column) []interface{} {
+	args := make([]interface{}, len(row))
+	for i, field := range row {
+		switch {
+		case field.Null:
+			args[i] = nil
+		case types[i] != nil && mysql5LoadBinaryType(types[i].DataType):
+			args[i] = []byte(field.Value)
+		default:
+			args[i] = field.Value
+		}
+	}
+	return args
+}
+
+// describeRow returns the row as a list of columns and values for errors.
+func (l *MySQL5Loader) describeRow(row Row) string {
+	values := make([]string, len(row))
+	for i, field := range row {
+		val := "NULL"
+		if !field.Null {
+			v := field.Value
+			if len(v) > mysql5LoadValueLength {
+				v = v[:mysql5LoadValueLength] + "..."
+			}
+			val = MySQL5Quote(v)
+		}
+		values[i] = fmt.Sprintf("%s=%s", MySQL5Backtick(field.Column), val)
+	}
+	return strings.Join(values, ", ")
+}
+
+// mysql5LoadBinaryType returns whether values of the data type are bytes
+// rather than strings.
+func mysql5LoadBinaryType(dataType string) bool {
+	return dataType == "bit" || fileBinaryType(dataType)
+}
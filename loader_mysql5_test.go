@@ -0,0 +1,212 @@
+package dbsample
+
+import (
+	"context"
+	gosql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testSQLConn is a database/sql driver connection which records the statements
+// executed on it, and fails the inserts of a row holding the value fail. The
+// only query it answers is SELECT @@hostname, @@port.
+type testSQLConn struct {
+	host  string
+	fail  string
+	execs []string
+}
+
+func (c *testSQLConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *testSQLConn) Driver() driver.Driver                        { return c }
+func (c *testSQLConn) Open(string) (driver.Conn, error)             { return c, nil }
+func (c *testSQLConn) Close() error                                 { return nil }
+func (c *testSQLConn) Begin() (driver.Tx, error)                    { return c, nil }
+func (c *testSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &testSQLStmt{conn: c, query: query}, nil
+}
+func (c *testSQLConn) Commit() error {
+	c.execs = append(c.execs, "COMMIT")
+	return nil
+}
+func (c *testSQLConn) Rollback() error {
+	c.execs = append(c.execs, "ROLLBACK")
+	return nil
+}
+
+type testSQLStmt struct {
+	conn  *testSQLConn
+	query string
+}
+
+func (s *testSQLStmt) Close() error  { return nil }
+func (s *testSQLStmt) NumInput() int { return -1 }
+func (s *testSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	if s.query != "SELECT @@hostname, @@port" {
+		return nil, errors.New("Query is not supported")
+	}
+	return &testSQLRows{values: [][]driver.Value{{s.conn.host, "3306"}}}, nil
+}
+
+type testSQLRows struct {
+	values [][]driver.Value
+}
+
+func (r *testSQLRows) Columns() []string { return []string{"@@hostname", "@@port"} }
+func (r *testSQLRows) Close() error      { return nil }
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+func (s *testSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.execs = append(s.conn.execs, "INSERT "+strconv.Itoa(strings.Count(s.query, "(?")))
+	for _, arg := range args {
+		if v, ok := arg.(string); ok && v == s.conn.fail {
+			return nil, errors.New("Duplicate entry")
+		}
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func testLoaderRows(n int, fail int) *Table {
+	table := NewTable()
+	table.Name = "users"
+	table.Columns = ColumnMap{
+		"id":   &Column{Name: "id", DataType: "int"},
+		"name": &Column{Name: "name", DataType: "varchar"},
+	}
+	for i := 1; i <= n; i++ {
+		name := "user"
+		if i == fail {
+			name = "bad"
+		}
+		table.Rows = append(table.Rows, Row{
+			Field{Column: "id", Value: strconv.Itoa(i)},
+			Field{Column: "name", Value: name},
+		})
+	}
+	return table
+}
+
+func testLoaderInsertRows(t *testing.T, table *Table) ([]string, error) {
+	c := &testSQLConn{fail: "bad"}
+	db := gosql.OpenDB(c)
+	defer db.Close()
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	err = NewMySQL5Loader(&DumpArgs{}, nil).insertRows(context.Background(), conn, table)
+	return c.execs, err
+}
+
+func TestMySQL5LoaderInsertRows(t *testing.T) {
+	execs, err := testLoaderInsertRows(t, testLoaderRows(250, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := []string{"INSERT 100", "INSERT 100", "INSERT 50", "COMMIT"}
+	if !reflect.DeepEqual(execs, ex) {
+		t.Errorf(`Expected '%v', got '%v'`, ex, execs)
+	}
+}
+
+func TestMySQL5LoaderInsertRowsFailure(t *testing.T) {
+	execs, err := testLoaderInsertRows(t, testLoaderRows(250, 150))
+	if err == nil {
+		t.Fatal("Expected the load to fail")
+	}
+	exErr := "Loading `users` failed on row 150 (`id`='150', `name`='bad'): Duplicate entry"
+	if err.Error() != exErr {
+		t.Errorf(`Expected '%s', got '%s'`, exErr, err.Error())
+	}
+	ex := []string{"INSERT 100", "INSERT 100"}
+	for i := 101; i <= 150; i++ {
+		ex = append(ex, "INSERT 1")
+	}
+	ex = append(ex, "ROLLBACK")
+	if !reflect.DeepEqual(execs, ex) {
+		t.Errorf(`Expected '%v', got '%v'`, ex, execs)
+	}
+}
+
+func TestMySQL5LoaderCheckTarget(t *testing.T) {
+	server := func(host, name string) *Server {
+		return &Server{db: gosql.OpenDB(&testSQLConn{host: host}), conn: &ConnectionArgs{Name: name}}
+	}
+	tests := []struct {
+		source *Server
+		target *Server
+		ok     bool
+	}{
+		{server("db1", "blog"), server("db1", "blog"), false},
+		{server("db1", "blog"), server("db1", "BLOG"), false},
+		{server("db1", "blog"), server("db1", "blog_copy"), true},
+		{server("db1", "blog"), server("db2", "blog"), true},
+	}
+	for _, test := range tests {
+		db := &testDatabase{name: test.source.conn.Name, server: test.source}
+		err := NewMySQL5Loader(&DumpArgs{}, test.target).checkTarget(db)
+		if (err == nil) != test.ok {
+			t.Errorf(`Expected ok %v loading '%s' into '%s', got '%v'`, test.ok, test.source.conn.Name, test.target.conn.Name, err)
+		}
+		test.source.db.Close()
+		test.target.db.Close()
+	}
+}
+
+func TestMySQL5LoadBatchRows(t *testing.T) {
+	tests := map[int]int{
+		0:      mysql5LoadBatchSize,
+		2:      mysql5LoadBatchSize,
+		655:    mysql5LoadBatchSize,
+		656:    99,
+		4096:   15,
+		100000: 1,
+	}
+	for cols, ex := range tests {
+		if ac := mysql5LoadBatchRows(cols); ac != ex {
+			t.Errorf(`Expected %d rows for %d columns, got %d`, ex, cols, ac)
+		}
+		if cols > 0 && cols < mysql5LoadMaxPlaceholders && cols*mysql5LoadBatchRows(cols) > mysql5LoadMaxPlaceholders {
+			t.Errorf(`Expected at most %d placeholders for %d columns`, mysql5LoadMaxPlaceholders, cols)
+		}
+	}
+}
+
+func TestMySQL5LoaderInsertSQL(t *testing.T) {
+	l := NewMySQL5Loader(&DumpArgs{}, nil)
+	tests := map[int]string{
+		1: "INSERT INTO `users` (`id`, `name`) VALUES (?, ?)",
+		3: "INSERT INTO `users` (`id`, `name`) VALUES (?, ?), (?, ?), (?, ?)",
+	}
+	for rows, ex := range tests {
+		ac := l.insertSQL("users", []string{"id", "name"}, rows)
+		if ac != ex {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+func TestMySQL5LoaderDescribeRow(t *testing.T) {
+	l := NewMySQL5Loader(&DumpArgs{}, nil)
+	row := Row{
+		Field{Column: "id", Value: "1"},
+		Field{Column: "email", Null: true},
+		Field{Column: "bio", Value: "0123456789012345678901234567890123456789"},
+	}
+	ex := "`id`='1', `email`=NULL, `bio`='01234567890123456789012345678901...'"
+	ac := l.describeRow(row)
+	if ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+}
@@ -0,0 +1,223 @@
+package dbsample
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// optionFileGroups are the option file groups read by dbsample, in the order
+// they are applied.
+var optionFileGroups = []string{"client", "dbsample"}
+
+// optionFileMaxDepth is the number of nested !include directives followed.
+const optionFileMaxDepth = 10
+
+// defaultOptionFiles returns the option files read when --defaults-file is not
+// given, in the order the mysql client reads them. Later files override the
+// options of earlier files.
+func defaultOptionFiles(extraFile string) []string {
+	files := []string{"/etc/my.cnf", "/etc/mysql/my.cnf"}
+	if extraFile != "" {
+		files = append(files, extraFile)
+	}
+	if u, err := user.Current(); err == nil {
+		files = append(files, filepath.Join(u.HomeDir, ".my.cnf"))
+	}
+	return files
+}
+
+// readOptionFiles reads the client options from the option files, and returns
+// the options by name. Missing files are skipped unless they are in required.
+func readOptionFiles(files []string, required map[string]bool) (map[string]string, error) {
+	groups := map[string]bool{}
+	for _, g := range optionFileGroups {
+		groups[g] = true
+	}
+	opts := map[string]string{}
+	for _, file := range files {
+		if _, err := os.Stat(file); os.IsNotExist(err) && !required[file] {
+			continue
+		}
+		if err := readOptionFile(file, groups, opts, 0); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+// readOptionFile reads the options in the groups from the file into opts. Like
+// the mysql client, world-writable files are ignored with a warning.
+// @see https://dev.mysql.com/doc/refman/5.7/en/option-files.html
+func readOptionFile(filename string, groups map[string]bool, opts map[string]string, depth int) error {
+	if depth > optionFileMaxDepth {
+		return fmt.Errorf("Option file %s: Too many nested !include directives", filename)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.Mode().Perm()&0002 != 0 {
+		warning("Warning: World-writable option file %s is ignored.", filename)
+		return nil
+	}
+
+	inGroup := false
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case line[0] == '[':
+			end := strings.IndexByte(line, ']')
+			if end == -1 {
+				return fmt.Errorf("Option file %s line %d: Invalid group %s", filename, n, line)
+			}
+			inGroup = groups[strings.ToLower(strings.TrimSpace(line[1:end]))]
+			continue
+		case strings.HasPrefix(line, "!include "):
+			if err = readOptionFile(optionFilePath(filename, line[9:]), groups, opts, depth+1); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(line, "!includedir "):
+			if err = readOptionDir(optionFilePath(filename, line[12:]), groups, opts, depth+1); err != nil {
+				return err
+			}
+			continue
+		case !inGroup:
+			continue
+		}
+
+		name, value := line, ""
+		if i := strings.IndexByte(line, '='); i != -1 {
+			name = line[:i]
+			if value, err = optionFileValue(line[i+1:]); err != nil {
+				return fmt.Errorf("Option file %s line %d: %s", filename, n, err)
+			}
+		}
+		name = strings.Replace(strings.ToLower(strings.TrimSpace(name)), "_", "-", -1)
+		opts[name] = value
+	}
+	return scanner.Err()
+}
+
+// readOptionDir reads each .cnf file in the directory in name order.
+func readOptionDir(dir string, groups map[string]bool, opts map[string]string, depth int) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".cnf") {
+			names = append(names, file.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err = readOptionFile(filepath.Join(dir, name), groups, opts, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// optionFilePath returns the path of an included file, relative to the
+// directory of the including file.
+func optionFilePath(filename, include string) string {
+	include = strings.TrimSpace(include)
+	if filepath.IsAbs(include) {
+		return include
+	}
+	return filepath.Join(filepath.Dir(filename), include)
+}
+
+// optionFileValue returns the value of an option, which may be quoted, with
+// escape sequences replaced. A # starts a comment unless it is quoted.
+func optionFileValue(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		quote := s[0]
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case quote:
+				rest := strings.TrimSpace(s[i+1:])
+				if rest != "" && rest[0] != '#' {
+					return "", fmt.Errorf("Unexpected text after the quoted value")
+				}
+				return optionFileUnescape(s[1:i]), nil
+			}
+		}
+		return "", fmt.Errorf("Unterminated quoted value")
+	}
+	if i := strings.IndexByte(s, '#'); i != -1 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return optionFileUnescape(s), nil
+}
+
+// optionFileUnescape replaces the escape sequences allowed in option values.
+func optionFileUnescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	b := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'b':
+			b.WriteByte('\b')
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 's':
+			b.WriteByte(' ')
+		case '\\':
+			b.WriteByte('\\')
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// readPasswordFile returns the first line of the file, warning when the file
+// can be read by other users.
+func readPasswordFile(filename string) (string, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return "", err
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		warning("Warning: Password file %s can be read by other users.", filename)
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	pass := string(data)
+	if i := strings.IndexByte(pass, '\n'); i != -1 {
+		pass = pass[:i]
+	}
+	return strings.TrimSuffix(pass, "\r"), nil
+}
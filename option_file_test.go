@@ -0,0 +1,119 @@
+package dbsample
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadOptionFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"my.cnf":    "# comment\n[mysqld]\nport = 3307\n\n[client]\nuser = admin\nport=3308\npassword = \"p#ss\\sword\" # comment\nhost = db1 # comment\n!include extra.cnf\n",
+		"extra.cnf": "[dbsample]\nhost='db2'\n[mysql]\nuser = other\n",
+		"user.cnf":  "[CLIENT]\nsocket_path = /tmp/mysql.sock\n",
+	}
+	for name, content := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts, err := readOptionFiles([]string{
+		filepath.Join(dir, "my.cnf"),
+		filepath.Join(dir, "missing.cnf"),
+		filepath.Join(dir, "user.cnf"),
+	}, map[string]bool{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := map[string]string{
+		"user":        "admin",
+		"port":        "3308",
+		"password":    "p#ss word",
+		"host":        "db2",
+		"socket-path": "/tmp/mysql.sock",
+	}
+	if len(opts) != len(ex) {
+		t.Errorf(`Expected '%v', got '%v'`, ex, opts)
+	}
+	for name, v := range ex {
+		if opts[name] != v {
+			t.Errorf(`Expected '%s', got '%s'`, v, opts[name])
+		}
+	}
+
+	if _, err = readOptionFiles([]string{filepath.Join(dir, "missing.cnf")}, map[string]bool{filepath.Join(dir, "missing.cnf"): true}); err == nil {
+		t.Error("Expected an error for a missing required option file")
+	}
+}
+
+func TestSetConnectionOptions(t *testing.T) {
+	env := map[string]string{"MYSQL_HOST": "env-host", "MYSQL_PWD": "env-pass", "MYSQL_TCP_PORT": "3310"}
+	getenv := func(name string) string {
+		return env[name]
+	}
+	conn := &ConnectionArgs{Host: "cli-host", User: "cli-user"}
+	opts := map[string]string{"host": "file-host", "port": "3309", "user": "file-user"}
+	if err := setConnectionOptions(conn, opts, getenv); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"cli-host": conn.Host,
+		"3309":     conn.Port,
+		"cli-user": conn.User,
+		"env-pass": conn.Pass,
+		"tcp":      conn.Protocol,
+	}
+	for ex, ac := range tests {
+		if ac != ex {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+
+	conn = &ConnectionArgs{User: "u"}
+	if err := setConnectionOptions(conn, map[string]string{}, func(string) string { return "" }); err != nil {
+		t.Fatal(err)
+	}
+	if conn.Host != "127.0.0.1" || conn.Port != "3306" {
+		t.Errorf(`Expected '%s', got '%s:%s'`, "127.0.0.1:3306", conn.Host, conn.Port)
+	}
+}
+
+func TestSetConnectionOptionsProtocol(t *testing.T) {
+	getenv := func(string) string { return "" }
+	tests := []struct {
+		conn *ConnectionArgs
+		opts map[string]string
+		dsn  string
+		err  bool
+	}{
+		{&ConnectionArgs{}, map[string]string{}, "u:@tcp(127.0.0.1:3306)/db?collation=utf8mb4_general_ci&interpolateParams=true", false},
+		{&ConnectionArgs{Host: "::1"}, map[string]string{}, "u:@tcp([::1]:3306)/db?collation=utf8mb4_general_ci&interpolateParams=true", false},
+		{&ConnectionArgs{Socket: "/tmp/mysql.sock"}, map[string]string{}, "u:@unix(/tmp/mysql.sock)/db?collation=utf8mb4_general_ci&interpolateParams=true", false},
+		{&ConnectionArgs{Host: "db1"}, map[string]string{"socket": "/tmp/mysql.sock"}, "u:@tcp(db1:3306)/db?collation=utf8mb4_general_ci&interpolateParams=true", false},
+		{&ConnectionArgs{Host: "localhost"}, map[string]string{"socket": "/tmp/mysql.sock"}, "u:@unix(/tmp/mysql.sock)/db?collation=utf8mb4_general_ci&interpolateParams=true", false},
+		{&ConnectionArgs{Protocol: "TCP", Socket: "/tmp/mysql.sock"}, map[string]string{}, "u:@tcp(127.0.0.1:3306)/db?collation=utf8mb4_general_ci&interpolateParams=true", false},
+		{&ConnectionArgs{Protocol: "socket"}, map[string]string{}, "", true},
+		{&ConnectionArgs{Protocol: "pipe"}, map[string]string{}, "", true},
+		{&ConnectionArgs{}, map[string]string{"protocol": "memory"}, "", true},
+		{&ConnectionArgs{Protocol: "pip"}, map[string]string{}, "", true},
+	}
+	for _, test := range tests {
+		test.conn.User = "u"
+		test.conn.Name = "db"
+		err := setConnectionOptions(test.conn, test.opts, getenv)
+		if (err != nil) != test.err {
+			t.Errorf(`Expected error %v, got '%v'`, test.err, err)
+			continue
+		}
+		if err == nil && test.conn.dsn() != test.dsn {
+			t.Errorf(`Expected '%s', got '%s'`, test.dsn, test.conn.dsn())
+		}
+	}
+}
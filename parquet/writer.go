@@ -0,0 +1,99 @@
+// Package parquet writes Apache Parquet files.
+//
+// The writer supports flat schemas of optional columns, which is all that is
+// needed to export sampled tables. The schema is a group of parquet-go nodes,
+// and the file format is written by github.com/parquet-go/parquet-go, which
+// splits each column into pages and row groups as they fill up.
+// @see https://github.com/apache/parquet-format
+package parquet
+
+import (
+	"fmt"
+	pq "github.com/parquet-go/parquet-go"
+	"io"
+)
+
+// Writer writes rows to a parquet file.
+type Writer struct {
+	w      *pq.Writer
+	fields []pq.Field
+	row    pq.Row
+}
+
+// NewWriter returns a new *Writer instance. The columns of the file are the
+// fields of the group, which parquet-go orders by name, and every field must be
+// optional.
+func NewWriter(w io.Writer, group pq.Group) *Writer {
+	return newWriter(w, group)
+}
+
+// newWriter returns a new *Writer instance with the given writer options.
+func newWriter(w io.Writer, group pq.Group, options ...pq.WriterOption) *Writer {
+	schema := pq.NewSchema("schema", group)
+	options = append([]pq.WriterOption{
+		schema,
+		pq.CreatedBy("dbsample", "", ""),
+	}, options...)
+	fields := schema.Fields()
+	return &Writer{
+		w:      pq.NewWriter(w, options...),
+		fields: fields,
+		row:    make(pq.Row, len(fields)),
+	}
+}
+
+// Fields returns the columns of the file in the order Write expects the values.
+func (w *Writer) Fields() []pq.Field {
+	return w.fields
+}
+
+// Write adds a row to the file, with one value for each field in the order
+// returned by Fields. Each value must be nil for NULL, or an int32, int64,
+// float32, float64 or []byte matching the physical type of the field.
+func (w *Writer) Write(row []interface{}) error {
+	if len(row) != len(w.fields) {
+		return fmt.Errorf("parquet: row has %d values, expected %d", len(row), len(w.fields))
+	}
+	for i, v := range row {
+		var value pq.Value
+		ok := false
+		switch w.fields[i].Type().Kind() {
+		case pq.Int32:
+			var n int32
+			n, ok = v.(int32)
+			value = pq.Int32Value(n)
+		case pq.Int64:
+			var n int64
+			n, ok = v.(int64)
+			value = pq.Int64Value(n)
+		case pq.Float:
+			var f float32
+			f, ok = v.(float32)
+			value = pq.FloatValue(f)
+		case pq.Double:
+			var f float64
+			f, ok = v.(float64)
+			value = pq.DoubleValue(f)
+		case pq.ByteArray:
+			var b []byte
+			b, ok = v.([]byte)
+			value = pq.ByteArrayValue(b)
+		}
+		switch {
+		case v == nil:
+			w.row[i] = pq.NullValue().Level(0, 0, i)
+		case !ok:
+			return fmt.Errorf("parquet: invalid value %T for column %s", v, w.fields[i].Name())
+		default:
+			w.row[i] = value.Level(0, 1, i)
+		}
+	}
+	_, err := w.w.WriteRows([]pq.Row{w.row})
+	return err
+}
+
+// Close writes the footer of the file. It does not close the underlying
+// writer.
+func (w *Writer) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,150 @@
+package parquet
+
+import (
+	"bytes"
+	pq "github.com/parquet-go/parquet-go"
+	"io"
+	"strconv"
+	"testing"
+)
+
+var testGroup = pq.Group{
+	"id":     pq.Optional(pq.Leaf(pq.Int64Type)),
+	"name":   pq.Optional(pq.String()),
+	"amount": pq.Optional(pq.Decimal(2, 10, pq.Int64Type)),
+	"score":  pq.Optional(pq.Leaf(pq.DoubleType)),
+}
+
+// testColumns is the order of the fields of testGroup.
+var testColumns = []string{"amount", "id", "name", "score"}
+
+func testOpenFile(t *testing.T, b []byte) *pq.File {
+	f, err := pq.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf, testGroup)
+	for i, field := range w.Fields() {
+		if field.Name() != testColumns[i] {
+			t.Errorf(`Expected '%s', got '%s'`, testColumns[i], field.Name())
+		}
+	}
+	if err := w.Write([]interface{}{int64(1050), int64(1), []byte("a"), 1.5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write([]interface{}{nil, int64(2), nil, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write([]interface{}{nil, int32(3), nil, nil}); err == nil {
+		t.Error("Expected an invalid value error")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := testOpenFile(t, buf.Bytes())
+	fields := f.Schema().Fields()
+	for i, name := range testColumns {
+		if fields[i].Name() != name || !fields[i].Optional() {
+			t.Errorf(`Expected optional column '%s', got '%s'`, name, fields[i].Name())
+		}
+	}
+	if ac := fields[0].Type().String(); ac != "DECIMAL(10,2)" {
+		t.Errorf(`Expected '%s', got '%s'`, "DECIMAL(10,2)", ac)
+	}
+
+	rows := make([]pq.Row, 3)
+	n, err := pq.NewReader(bytes.NewReader(buf.Bytes())).ReadRows(rows)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf(`Expected '%d', got '%d'`, 2, n)
+	}
+	if rows[0][0].Int64() != 1050 || rows[0][1].Int64() != 1 || string(rows[0][2].ByteArray()) != "a" || rows[0][3].Double() != 1.5 {
+		t.Errorf(`Unexpected row %v`, rows[0])
+	}
+	if !rows[1][0].IsNull() || rows[1][1].Int64() != 2 || !rows[1][2].IsNull() || !rows[1][3].IsNull() {
+		t.Errorf(`Unexpected row %v`, rows[1])
+	}
+}
+
+func TestWriterPages(t *testing.T) {
+	buf := &bytes.Buffer{}
+	group := pq.Group{"id": testGroup["id"], "name": testGroup["name"]}
+	w := newWriter(buf, group, pq.PageBufferSize(1024))
+	const count = 5000
+	for i := 0; i < count; i++ {
+		name := []byte("name " + strconv.Itoa(i))
+		if i%10 == 0 {
+			name = nil
+		}
+		var v interface{}
+		if name != nil {
+			v = name
+		}
+		if err := w.Write([]interface{}{int64(i), v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := testOpenFile(t, buf.Bytes())
+	if f.NumRows() != count {
+		t.Errorf(`Expected '%d', got '%d'`, count, f.NumRows())
+	}
+	for _, chunk := range f.RowGroups()[0].ColumnChunks() {
+		pages := chunk.Pages()
+		n := 0
+		for {
+			_, err := pages.ReadPage()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			n++
+		}
+		pages.Close()
+		if n < 2 {
+			t.Errorf(`Expected column %d to be split into pages, got %d`, chunk.Column(), n)
+		}
+	}
+
+	r := pq.NewReader(bytes.NewReader(buf.Bytes()))
+	rows := make([]pq.Row, 100)
+	read := 0
+	for {
+		n, err := r.ReadRows(rows)
+		for _, row := range rows[:n] {
+			i := read
+			read++
+			if row[0].Int64() != int64(i) {
+				t.Fatalf(`Expected '%d', got '%d'`, i, row[0].Int64())
+			}
+			if row[1].IsNull() != (i%10 == 0) {
+				t.Fatalf(`Expected NULL %v for row %d`, i%10 == 0, i)
+			}
+			if !row[1].IsNull() && string(row[1].ByteArray()) != "name "+strconv.Itoa(i) {
+				t.Fatalf(`Expected '%s', got '%s'`, "name "+strconv.Itoa(i), row[1].ByteArray())
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if read != count {
+		t.Errorf(`Expected '%d', got '%d'`, count, read)
+	}
+}
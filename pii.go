@@ -0,0 +1,268 @@
+package dbsample
+
+import (
+	"fmt"
+	"github.com/headzoo/dbsample/filters"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// piiValueThreshold is the fraction of non-empty sampled values which must
+// match a detector before the column is reported.
+const piiValueThreshold = 0.5
+
+// piiColumnNames matches column names which commonly hold personal data.
+var piiColumnNames = regexp.MustCompile(
+	`(?i)(e_?mail|phone|mobile|fax|ssn|social_?security|passport|national_?id|tax_?id|` +
+		`birth|dob|address|street|postcode|zip_?code|iban|card_?num|cc_?num|credit_?card|` +
+		`first_?name|last_?name|surname|full_?name|ip_?addr|password|passwd|secret|token)`,
+)
+
+var (
+	piiEmail = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+	piiPhone = regexp.MustCompile(`^\+?[\d\s().\-]{7,20}$`)
+	piiCard  = regexp.MustCompile(`^[\d\s\-]{13,23}$`)
+	piiSSN   = regexp.MustCompile(`^\d{3}-\d{2}-\d{4}$`)
+	piiNINO  = regexp.MustCompile(`^[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]\s?\d{2}\s?\d{2}\s?\d{2}\s?[A-D]$`)
+	piiJWT   = regexp.MustCompile(`^eyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*$`)
+)
+
+// piiDetector recognises a single kind of personal data in a value.
+type piiDetector struct {
+	Name  string
+	Match func(string) bool
+}
+
+// piiDetectors are tried, in order, against each sampled value. Only the first
+// matching detector is counted for a value.
+var piiDetectors = []piiDetector{
+	{"email addresses", piiEmail.MatchString},
+	{"JSON web tokens", piiJWT.MatchString},
+	{"card numbers", piiIsCardNumber},
+	{"national ID numbers", func(v string) bool {
+		return piiSSN.MatchString(v) || piiNINO.MatchString(strings.ToUpper(v))
+	}},
+	{"IP addresses", func(v string) bool {
+		return net.ParseIP(v) != nil
+	}},
+	{"phone numbers", piiIsPhoneNumber},
+}
+
+// piiNumberDetectors are tried against the values of integer columns.
+var piiNumberDetectors = []piiDetector{
+	{"card numbers", piiIsCardNumber},
+	{"phone numbers", piiIsPhoneNumber},
+}
+
+// piiScannedTypes are the data types whose values are inspected.
+var piiScannedTypes = map[string]bool{
+	"char":       true,
+	"varchar":    true,
+	"tinytext":   true,
+	"text":       true,
+	"mediumtext": true,
+	"longtext":   true,
+}
+
+// piiIntegerTypes are the integer types whose values are inspected for card
+// and phone numbers.
+var piiIntegerTypes = map[string]bool{
+	"int":       true,
+	"integer":   true,
+	"mediumint": true,
+	"bigint":    true,
+}
+
+// PIIFinding describes a column which may contain personal data.
+type PIIFinding struct {
+	TableName  string
+	ColumnName string
+	Reasons    []string
+}
+
+// scanPII inspects the names and sampled values of each column which is not
+// covered by a filter, and returns the columns which look like personal data.
+// The string and number values in json columns are inspected separately for
+// each path, skipping those selected by a json filter.
+func scanPII(tables TableGraph) []*PIIFinding {
+	findings := []*PIIFinding{}
+	for _, table := range tables {
+		names := []string{}
+		for name := range table.Columns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if Filters.CoversColumn(table.Name, name) {
+				continue
+			}
+			reasons := []string{}
+			if piiColumnNames.MatchString(name) && !Filters.HasFilter(table.Name, name) {
+				reasons = append(reasons, "column name")
+			}
+			dataType := table.Columns[name].DataType
+			switch {
+			case dataType == "json":
+				reasons = append(reasons, piiScanJSON(table, name)...)
+			case piiScannedTypes[dataType]:
+				reasons = append(reasons, piiScanValues(piiColumnValues(table.Rows, name), piiDetectors)...)
+			case piiIntegerTypes[dataType] && !piiIsKeyColumn(table, name):
+				reasons = append(reasons, piiScanValues(piiColumnValues(table.Rows, name), piiNumberDetectors)...)
+			}
+			if len(reasons) > 0 {
+				findings = append(findings, &PIIFinding{
+					TableName:  table.Name,
+					ColumnName: name,
+					Reasons:    reasons,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// piiColumnValues returns the non-NULL values of the column.
+func piiColumnValues(rows Rows, column string) []string {
+	values := []string{}
+	for _, row := range rows {
+		for _, field := range row {
+			if field.Column == column && !field.Null {
+				values = append(values, field.Value)
+			}
+		}
+	}
+	return values
+}
+
+// piiScanJSON returns a description of each detector which matches enough of
+// the values found at a path in the json documents of the column. Values
+// selected by a json filter are skipped.
+func piiScanJSON(table *Table, column string) []string {
+	paths := []string{}
+	values := map[string][]string{}
+	for _, doc := range piiColumnValues(table.Rows, column) {
+		leaves, err := filters.JSONLeaves(doc)
+		if err != nil {
+			continue
+		}
+		for _, leaf := range leaves {
+			if Filters.CoversJSONLeaf(table.Name, column, leaf) {
+				continue
+			}
+			if _, ok := values[leaf.Path]; !ok {
+				paths = append(paths, leaf.Path)
+			}
+			values[leaf.Path] = append(values[leaf.Path], leaf.Value)
+		}
+	}
+	sort.Strings(paths)
+
+	reasons := []string{}
+	for _, path := range paths {
+		for _, reason := range piiScanValues(values[path], piiDetectors) {
+			reasons = append(reasons, fmt.Sprintf("%s at %s", reason, path))
+		}
+	}
+	return reasons
+}
+
+// piiIsKeyColumn returns whether the column is part of a unique index or a
+// foreign key, whose integer values are identifiers rather than personal data.
+func piiIsKeyColumn(table *Table, column string) bool {
+	for _, index := range table.Indexes {
+		if index.Unique && stringsContain(index.Columns, column) {
+			return true
+		}
+	}
+	for _, fk := range table.Constraints {
+		if fk.ReferencedColumnName == column {
+			return true
+		}
+	}
+	return false
+}
+
+// piiScanValues returns a description of each detector which matches enough
+// of the sampled values.
+func piiScanValues(values []string, detectors []piiDetector) []string {
+	total := 0
+	counts := make([]int, len(detectors))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		total++
+		for i, d := range detectors {
+			if d.Match(v) {
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	reasons := []string{}
+	for i, d := range detectors {
+		if total > 0 && float64(counts[i])/float64(total) >= piiValueThreshold {
+			reasons = append(reasons, fmt.Sprintf("%s (%d/%d sampled values)", d.Name, counts[i], total))
+		}
+	}
+	return reasons
+}
+
+// writePIIReport writes the findings of a scan in a human readable format.
+func writePIIReport(w io.Writer, findings []*PIIFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "PII scan: no unfiltered columns look like personal data.")
+		return
+	}
+	fmt.Fprintf(w, "PII scan: %d unfiltered column(s) may contain personal data.\n", len(findings))
+	for _, f := range findings {
+		fmt.Fprintf(w, "  %s.%s: %s\n", f.TableName, f.ColumnName, strings.Join(f.Reasons, ", "))
+	}
+}
+
+// piiIsCardNumber returns whether v is a 13 to 19 digit number which passes
+// the Luhn checksum.
+func piiIsCardNumber(v string) bool {
+	if !piiCard.MatchString(v) {
+		return false
+	}
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(v)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// piiIsPhoneNumber returns whether v looks like a phone number with 7 to 15
+// digits.
+func piiIsPhoneNumber(v string) bool {
+	if !piiPhone.MatchString(v) {
+		return false
+	}
+	digits := 0
+	for _, c := range v {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	return digits >= 7 && digits <= 15
+}
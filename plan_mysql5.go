@@ -0,0 +1,93 @@
+package dbsample
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Plan writes what a dump would do without reading any row data: the order the
+// tables are sampled in, grouped by dependency level, the foreign keys of each
+// table, the number of rows in each table estimated by the server, and the
+// statement which would select the rows of each table. Tables which would be
+// skipped are reported with a warning. The estimates come from
+// INFORMATION_SCHEMA.TABLES and may be inaccurate, so skips are only predicted
+// for tables which reference a table estimated to be empty.
+func (db *MySQL5Database) Plan(w io.Writer) error {
+	tables, err := db.loadTables()
+	if err != nil {
+		return err
+	}
+	db.writePlan(w, tables)
+	return nil
+}
+
+// writePlan writes the plan of the tables, which must be sorted by their
+// constraints.
+func (db *MySQL5Database) writePlan(w io.Writer, tables TableGraph) {
+	fmt.Fprintf(w, "Plan for %s, limited to %d rows per table.\n", MySQL5Comment(MySQL5Backtick(db.name)), db.server.args.Limit)
+	skipTables := map[string]bool{}
+	level := -1
+	for _, table := range tables {
+		if table.Level != level {
+			level = table.Level
+			fmt.Fprintf(w, "\nLevel %d\n", level)
+		}
+		fmt.Fprintf(w, "\n  %s (about %d rows)\n", MySQL5Comment(MySQL5Backtick(table.Name)), table.EstimatedRows)
+
+		refs := map[string][]string{}
+		for _, fk := range table.Constraints {
+			source := "detected"
+			if fk.UserDefined {
+				source = "--constraint"
+			}
+			parent := MySQL5Backtick(fk.TableName) + "." + MySQL5Backtick(fk.ColumnName)
+			fmt.Fprintf(w, "    %s references %s (%s)\n", MySQL5Comment(MySQL5Backtick(fk.ReferencedColumnName)), MySQL5Comment(parent), source)
+			refs[fk.ReferencedColumnName] = append(refs[fk.ReferencedColumnName], parent)
+		}
+		wheres := []string{}
+		for col, values := range refs {
+			wheres = append(wheres, fmt.Sprintf("%s IN(<sampled %s>)", MySQL5Backtick(col), strings.Join(values, ", ")))
+		}
+		sort.Strings(wheres)
+		fmt.Fprintf(w, "    %s\n", MySQL5Comment(db.selectRowsSQL(table, wheres)))
+
+		if resolveTableSkipped(table, skipTables) {
+			fmt.Fprintf(w, "    Warning: Would be skipped, references a skipped or empty table.\n")
+			skipTables[table.Name] = true
+			continue
+		}
+		if table.EstimatedRows == 0 {
+			for _, t := range tables {
+				for _, fk := range t.Constraints {
+					if fk.TableName == table.Name && !skipTables[t.Name] {
+						fmt.Fprintf(w, "    Warning: Appears empty, %s would be skipped.\n", MySQL5Comment(MySQL5Backtick(t.Name)))
+						skipTables[t.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	fmt.Fprint(w, "\nDependencies\n\n")
+	displayGraph(w, tables)
+}
+
+// Graph writes the tables of the database and the foreign keys between them
+// in the given graph format. Circular dependencies are marked in the graph
+// rather than returned as an error.
+func (db *MySQL5Database) Graph(w io.Writer, format string) error {
+	tables, err := db.queryTables()
+	if err != nil {
+		return err
+	}
+	g := NewGraph(db.name, tables)
+	switch format {
+	case GraphFormatMermaid:
+		g.WriteMermaid(w)
+	default:
+		g.WriteDOT(w)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package dbsample
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMySQL5DatabaseWritePlan(t *testing.T) {
+	users := NewTable()
+	users.Name = "users"
+	users.EstimatedRows = 1200
+	users.Columns = ColumnMap{
+		"id":   &Column{Name: "id", OrdinalPosition: 1},
+		"name": &Column{Name: "name", OrdinalPosition: 2},
+	}
+	groups := NewTable()
+	groups.Name = "groups"
+	groups.Columns = ColumnMap{"id": &Column{Name: "id", OrdinalPosition: 1}}
+	posts := NewTable()
+	posts.Name = "posts"
+	posts.EstimatedRows = 5300
+	posts.Columns = ColumnMap{
+		"id":       &Column{Name: "id", OrdinalPosition: 1},
+		"user_id":  &Column{Name: "user_id", OrdinalPosition: 2},
+		"group_id": &Column{Name: "group_id", OrdinalPosition: 3},
+	}
+	posts.Constraints = []*Constraint{
+		{TableName: "users", ColumnName: "id", ReferencedColumnName: "user_id"},
+		{TableName: "groups", ColumnName: "id", ReferencedColumnName: "group_id", UserDefined: true},
+	}
+	tables, err := resolveTableConstraints(TableGraph{posts, users, groups})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := &MySQL5Database{name: "blog", server: &Server{args: &DumpArgs{Limit: 100}}}
+	buf := &bytes.Buffer{}
+	db.writePlan(buf, tables)
+	ex := "Plan for `blog`, limited to 100 rows per table.\n" +
+		"\nLevel 0\n" +
+		"\n  `groups` (about 0 rows)\n" +
+		"    SELECT `id` FROM `groups` LIMIT 100\n" +
+		"    Warning: Appears empty, `posts` would be skipped.\n" +
+		"\n  `users` (about 1200 rows)\n" +
+		"    SELECT `id`, `name` FROM `users` LIMIT 100\n" +
+		"\nLevel 1\n" +
+		"\n  `posts` (about 5300 rows)\n" +
+		"    `user_id` references `users`.`id` (detected)\n" +
+		"    `group_id` references `groups`.`id` (--constraint)\n" +
+		"    SELECT `id`, `user_id`, `group_id` FROM `posts` WHERE `group_id` IN(<sampled `groups`.`id`>) AND `user_id` IN(<sampled `users`.`id`>) LIMIT 100\n" +
+		"    Warning: Would be skipped, references a skipped or empty table.\n" +
+		"\nDependencies\n\n" +
+		"groups\n\n" +
+		"users\n\n" +
+		"posts\n" +
+		"-- 1. users\n" +
+		"-- 1. groups\n\n"
+	if ac := buf.String(); ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+}
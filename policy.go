@@ -0,0 +1,136 @@
+package dbsample
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	PolicyPublic   = "public"
+	PolicyFiltered = "filtered"
+	PolicyDropped  = "dropped"
+)
+
+// Policy stores the classification of each column in the database. Every
+// column must be classified before a dump is allowed, so columns added by a
+// migration cannot leak into dumps until someone has looked at them.
+//
+// A policy file has one classification per line, followed by a table.column,
+// where the column may be "*" to classify every column in the table. Dropped
+// columns are never read from the server and are left out of the dump, so they
+// are loaded with their default values.
+//
+//	# comment
+//	public   users.id
+//	filtered users.email
+//	dropped  users.password
+//	public   posts.*
+type Policy struct {
+	classes map[string]string
+}
+
+// LoadPolicy reads a policy file.
+func LoadPolicy(filename string) (*Policy, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p := &Policy{
+		classes: map[string]string{},
+	}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) != 2 || strings.Count(parts[1], ".") != 1 {
+			return nil, fmt.Errorf(`Invalid policy "%s" on line %d of %s`, line, n, filename)
+		}
+		switch parts[0] {
+		case PolicyPublic, PolicyFiltered, PolicyDropped:
+		default:
+			return nil, fmt.Errorf(`Invalid classification "%s" on line %d of %s`, parts[0], n, filename)
+		}
+		p.classes[parts[1]] = parts[0]
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Classification returns the classification of the column, or an empty string
+// when the column has not been classified.
+func (p *Policy) Classification(tableName, columnName string) string {
+	if c, ok := p.classes[tableName+"."+columnName]; ok {
+		return c
+	}
+	return p.classes[tableName+".*"]
+}
+
+// Check returns a message for each column which breaks the policy. Dropped
+// columns should be removed by Drop before calling Check.
+func (p *Policy) Check(tables TableGraph) []string {
+	violations := []string{}
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			switch p.Classification(table.Name, col.Name) {
+			case "":
+				violations = append(violations, fmt.Sprintf("Column %s.%s has not been classified.", MySQL5Backtick(table.Name), MySQL5Backtick(col.Name)))
+			case PolicyFiltered:
+				if !Filters.HasFilter(table.Name, col.Name) {
+					violations = append(violations, fmt.Sprintf("Column %s.%s is classified as filtered but has no filter.", MySQL5Backtick(table.Name), MySQL5Backtick(col.Name)))
+				}
+			}
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// Drop removes each column classified as dropped from its table, before the
+// rows are selected. Columns which are part of a unique index or a foreign key
+// cannot be dropped, because their values are needed to sample the rows and
+// their default values would not load.
+func (p *Policy) Drop(tables TableGraph) error {
+	for _, table := range tables {
+		for _, col := range table.Columns.Ordered() {
+			if p.Classification(table.Name, col.Name) != PolicyDropped {
+				continue
+			}
+			if policyIsKeyColumn(tables, table, col.Name) {
+				return fmt.Errorf("Column %s.%s is classified as dropped but is part of a unique index or foreign key.", MySQL5Backtick(table.Name), MySQL5Backtick(col.Name))
+			}
+			delete(table.Columns, col.Name)
+		}
+	}
+	return nil
+}
+
+// policyIsKeyColumn returns whether the column is part of a unique index of
+// the table, or of a foreign key from or to the table.
+func policyIsKeyColumn(tables TableGraph, table *Table, column string) bool {
+	for _, index := range table.Indexes {
+		if index.Unique && stringsContain(index.Columns, column) {
+			return true
+		}
+	}
+	for _, t := range tables {
+		for _, fk := range t.Constraints {
+			if t == table && fk.ReferencedColumnName == column {
+				return true
+			}
+			if fk.TableName == table.Name && fk.ColumnName == column {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package dbsample
+
+import (
+	"github.com/headzoo/dbsample/filters"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func testLoadPolicy(t *testing.T, contents string) *Policy {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "policy")
+	if err = ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func testPolicyTables() TableGraph {
+	users := NewTable()
+	users.Name = "users"
+	users.Columns = ColumnMap{
+		"id":       &Column{Name: "id", OrdinalPosition: 1},
+		"email":    &Column{Name: "email", OrdinalPosition: 2},
+		"password": &Column{Name: "password", OrdinalPosition: 3},
+		"bio":      &Column{Name: "bio", OrdinalPosition: 4},
+	}
+	users.Indexes = []*Index{{Name: "PRIMARY", Unique: true, Columns: []string{"id"}}}
+	posts := NewTable()
+	posts.Name = "posts"
+	posts.Columns = ColumnMap{
+		"id":      &Column{Name: "id", OrdinalPosition: 1},
+		"user_id": &Column{Name: "user_id", OrdinalPosition: 2},
+	}
+	posts.Constraints = []*Constraint{{TableName: "users", ColumnName: "id", ReferencedColumnName: "user_id"}}
+	return TableGraph{users, posts}
+}
+
+func TestPolicyClassification(t *testing.T) {
+	p := testLoadPolicy(t, "# comment\n\npublic users.id\nfiltered users.email\ndropped users.password\npublic posts.*\n")
+	tests := []struct {
+		table  string
+		column string
+		ex     string
+	}{
+		{"users", "id", PolicyPublic},
+		{"users", "email", PolicyFiltered},
+		{"users", "password", PolicyDropped},
+		{"users", "bio", ""},
+		{"posts", "user_id", PolicyPublic},
+	}
+	for _, test := range tests {
+		if ac := p.Classification(test.table, test.column); ac != test.ex {
+			t.Errorf(`Expected '%s' for '%s.%s', got '%s'`, test.ex, test.table, test.column, ac)
+		}
+	}
+}
+
+func TestLoadPolicyInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, contents := range []string{"public users", "secret users.id", "public users.id extra"} {
+		filename := filepath.Join(dir, "policy")
+		if err = ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = LoadPolicy(filename); err == nil {
+			t.Errorf(`Expected an error for '%s'`, contents)
+		}
+	}
+}
+
+func TestPolicyCheckAndDrop(t *testing.T) {
+	defer func(f *filters.FilterController) {
+		Filters = f
+	}(Filters)
+	Filters = filters.NewFilterController()
+	if err := Filters.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	p := testLoadPolicy(t, "public users.id\nfiltered users.email\ndropped users.password\npublic posts.*\n")
+	tables := testPolicyTables()
+	if err := p.Drop(tables); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tables[0].Columns["password"]; ok {
+		t.Errorf(`Expected column 'password' to be dropped`)
+	}
+	if len(tables[0].Columns) != 3 {
+		t.Errorf(`Expected 3 columns, got %d`, len(tables[0].Columns))
+	}
+
+	ex := []string{
+		"Column `users`.`bio` has not been classified.",
+		"Column `users`.`email` is classified as filtered but has no filter.",
+	}
+	if ac := p.Check(tables); !reflect.DeepEqual(ac, ex) {
+		t.Errorf(`Expected '%v', got '%v'`, ex, ac)
+	}
+	if err := Filters.SetCommands([]string{"empty users.email"}); err != nil {
+		t.Fatal(err)
+	}
+	ex = []string{"Column `users`.`bio` has not been classified."}
+	if ac := p.Check(tables); !reflect.DeepEqual(ac, ex) {
+		t.Errorf(`Expected '%v', got '%v'`, ex, ac)
+	}
+}
+
+func TestPolicyDropKeyColumn(t *testing.T) {
+	tests := []string{
+		"dropped users.id\n",
+		"dropped posts.user_id\n",
+	}
+	for _, contents := range tests {
+		p := testLoadPolicy(t, contents)
+		if err := p.Drop(testPolicyTables()); err == nil {
+			t.Errorf(`Expected an error for '%s'`, contents)
+		}
+	}
+}
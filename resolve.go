@@ -3,11 +3,15 @@ package dbsample
 import (
 	"fmt"
 	"github.com/deckarep/golang-set"
-	"os"
+	"io"
+	"sort"
 	"strings"
+	"sync"
 )
 
-// resolveTableGraph resolves table constraints.
+// resolveTableConstraints sorts the tables so each table comes after the tables
+// it references. Tables are grouped into levels, where the tables in a level
+// only reference tables in earlier levels.
 func resolveTableConstraints(graph TableGraph) (TableGraph, error) {
 	tableNames := make(map[string]*Table)
 	tableFKs := make(map[string]mapset.Set)
@@ -21,6 +25,7 @@ func resolveTableConstraints(graph TableGraph) (TableGraph, error) {
 	}
 
 	var resolved TableGraph
+	level := 0
 	for len(tableFKs) != 0 {
 		readySet := mapset.NewSet()
 		for tableName, fks := range tableFKs {
@@ -35,14 +40,21 @@ func resolveTableConstraints(graph TableGraph) (TableGraph, error) {
 				for fk := range fks.Iter() {
 					f = append(f, fk.(string))
 				}
-				s = append(s, fmt.Sprintf("`%s` = %s", tableName, strings.Join(f, ", ")))
+				s = append(s, fmt.Sprintf("%s = %s", MySQL5Backtick(tableName), strings.Join(f, ", ")))
 			}
 			return resolved, fmt.Errorf("Circular dependency found -> %s", strings.Join(s, ", "))
 		}
+		ready := []string{}
 		for tableName := range readySet.Iter() {
-			delete(tableFKs, tableName.(string))
-			resolved = append(resolved, tableNames[tableName.(string)])
+			ready = append(ready, tableName.(string))
 		}
+		sort.Strings(ready)
+		for _, tableName := range ready {
+			delete(tableFKs, tableName)
+			tableNames[tableName].Level = level
+			resolved = append(resolved, tableNames[tableName])
+		}
+		level++
 		for tableName, fks := range tableFKs {
 			diff := fks.Difference(readySet)
 			tableFKs[tableName] = diff
@@ -52,87 +64,176 @@ func resolveTableConstraints(graph TableGraph) (TableGraph, error) {
 	return resolved, nil
 }
 
+// resolveViewDependencies sorts the views so each view comes after the views
+// it selects from.
+func resolveViewDependencies(views ViewGraph) (ViewGraph, error) {
+	viewNames := make(map[string]*View)
+	viewDeps := make(map[string]mapset.Set)
+	for _, view := range views {
+		depSet := mapset.NewSet()
+		for _, dep := range view.Dependencies {
+			depSet.Add(dep)
+		}
+		viewNames[view.Name] = view
+		viewDeps[view.Name] = depSet
+	}
+
+	var resolved ViewGraph
+	for len(viewDeps) != 0 {
+		ready := []string{}
+		for viewName, deps := range viewDeps {
+			if deps.Cardinality() == 0 {
+				ready = append(ready, viewName)
+			}
+		}
+		if len(ready) == 0 {
+			s := []string{}
+			for viewName := range viewDeps {
+				s = append(s, MySQL5Backtick(viewName))
+			}
+			sort.Strings(s)
+			return resolved, fmt.Errorf("Circular view dependency found -> %s", strings.Join(s, ", "))
+		}
+		sort.Strings(ready)
+		readySet := mapset.NewSet()
+		for _, viewName := range ready {
+			delete(viewDeps, viewName)
+			readySet.Add(viewName)
+			resolved = append(resolved, viewNames[viewName])
+		}
+		for viewName, deps := range viewDeps {
+			viewDeps[viewName] = deps.Difference(readySet)
+		}
+	}
+
+	return resolved, nil
+}
+
 type resolveTableRowsFunc func(table *Table, cond map[string]mapset.Set) (Rows, error)
 
-// resolveTableRows...
-func resolveTableRows(tables TableGraph, fn resolveTableRowsFunc) (err error) {
+// resolveTableRows selects the rows of each table, limited to the rows which
+// are referenced by the tables selected before it. The tables of each level are
+// selected concurrently by up to threads goroutines. The referenced rows are
+// collected in table order once the whole level has been selected, so the
+// result does not depend on the number of threads.
+func resolveTableRows(tables TableGraph, threads int, fn resolveTableRowsFunc) (err error) {
+	if threads < 1 {
+		threads = 1
+	}
 	fkRows := make(map[string]map[string]mapset.Set)
 	skipTables := make(map[string]bool)
-	for _, table := range tables {
-		skip := false
-		if _, ok := skipTables[table.Name]; ok {
-			continue
+	for start := 0; start < len(tables); {
+		end := start + 1
+		for end < len(tables) && tables[end].Level == tables[start].Level {
+			end++
 		}
-		for _, fk := range table.Constraints {
-			if _, ok := skipTables[fk.TableName]; ok {
-				skip = true
+		level := TableGraph{}
+		for _, table := range tables[start:end] {
+			if !resolveTableSkipped(table, skipTables) {
+				level = append(level, table)
 			}
 		}
-		if skip {
-			continue
+		start = end
+
+		errs := make([]error, len(level))
+		sem := make(chan bool, threads)
+		var wg sync.WaitGroup
+		for i, table := range level {
+			cond := map[string]mapset.Set{}
+			if _, ok := fkRows[table.Name]; ok {
+				cond = fkRows[table.Name]
+			}
+			wg.Add(1)
+			sem <- true
+			go func(i int, table *Table, cond map[string]mapset.Set) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				table.Rows, errs[i] = fn(table, cond)
+			}(i, table, cond)
+		}
+		wg.Wait()
+		for _, err = range errs {
+			if err != nil {
+				return
+			}
 		}
 
-		cond := map[string]mapset.Set{}
-		if _, ok := fkRows[table.Name]; ok {
-			cond = fkRows[table.Name]
+		for _, table := range level {
+			resolveReferencedRows(tables, table, fkRows, skipTables)
 		}
-		if table.Rows, err = fn(table, cond); err != nil {
-			return
+	}
+
+	return
+}
+
+// resolveTableSkipped returns whether the table, or a table it references, has
+// been skipped.
+func resolveTableSkipped(table *Table, skipTables map[string]bool) bool {
+	if _, ok := skipTables[table.Name]; ok {
+		return true
+	}
+	for _, fk := range table.Constraints {
+		if _, ok := skipTables[fk.TableName]; ok {
+			return true
 		}
+	}
+	return false
+}
 
-		// Should we save these rows because another table depends on them?
-		for _, t := range tables {
-			for _, fk := range t.Constraints {
-				if fk.TableName == table.Name {
-					if len(table.Rows) == 0 {
-						warning("Skipping `%s`, references empty table `%s`.", t.Name, fk.TableName)
-						skipTables[t.Name] = true
-						continue
-					}
-					if _, ok := fkRows[t.Name]; !ok {
-						fkRows[t.Name] = make(map[string]mapset.Set)
-					}
-					if _, ok := fkRows[t.Name][fk.ReferencedColumnName]; !ok {
-						fkRows[t.Name][fk.ReferencedColumnName] = mapset.NewSet()
-					}
-					for _, row := range table.Rows {
-						for _, field := range row {
-							if field.Column == fk.ColumnName {
-								fkRows[t.Name][fk.ReferencedColumnName].Add(field.Value)
-							}
+// resolveReferencedRows saves the values of the table rows which are referenced
+// by other tables.
+func resolveReferencedRows(tables TableGraph, table *Table, fkRows map[string]map[string]mapset.Set, skipTables map[string]bool) {
+	for _, t := range tables {
+		for _, fk := range t.Constraints {
+			if fk.TableName == table.Name {
+				if len(table.Rows) == 0 {
+					warning("Skipping %s, references empty table %s.", MySQL5Backtick(t.Name), MySQL5Backtick(fk.TableName))
+					skipTables[t.Name] = true
+					continue
+				}
+				if _, ok := fkRows[t.Name]; !ok {
+					fkRows[t.Name] = make(map[string]mapset.Set)
+				}
+				if _, ok := fkRows[t.Name][fk.ReferencedColumnName]; !ok {
+					fkRows[t.Name][fk.ReferencedColumnName] = mapset.NewSet()
+				}
+				for _, row := range table.Rows {
+					for _, field := range row {
+						if field.Column == fk.ColumnName && !field.Null {
+							fkRows[t.Name][fk.ReferencedColumnName].Add(field.Value)
 						}
 					}
 				}
 			}
 		}
 	}
-
-	return
 }
 
 var displayTables map[string]*Table
 
-// displayGraph...
-func displayGraph(graph TableGraph) {
+// displayGraph writes each table followed by the tree of tables it references.
+func displayGraph(w io.Writer, graph TableGraph) {
 	displayTables = map[string]*Table{}
 	for _, table := range graph {
 		displayTables[table.Name] = table
 	}
 	for _, table := range graph {
-		fmt.Fprintf(os.Stderr, "%s\n", table.Name)
-		displayConstraints(table.Constraints, 1)
-		fmt.Fprint(os.Stderr, "\n")
+		fmt.Fprintf(w, "%s\n", table.Name)
+		displayConstraints(w, table.Constraints, 1)
+		fmt.Fprint(w, "\n")
 	}
 }
 
 // displayConstraints...
-func displayConstraints(fks []*Constraint, indent int) {
+func displayConstraints(w io.Writer, fks []*Constraint, indent int) {
 	tabs := strings.Repeat("--", indent)
 	for _, fk := range fks {
-		fmt.Fprintf(os.Stderr, "%s %d. %s\n", tabs, indent, fk.TableName)
-		if len(displayTables[fk.TableName].Constraints) > 0 {
+		fmt.Fprintf(w, "%s %d. %s\n", tabs, indent, fk.TableName)
+		if t, ok := displayTables[fk.TableName]; ok && len(t.Constraints) > 0 {
 			indent++
-			displayConstraints(displayTables[fk.TableName].Constraints, indent)
+			displayConstraints(w, t.Constraints, indent)
 			indent--
 		}
 	}
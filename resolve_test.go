@@ -0,0 +1,84 @@
+package dbsample
+
+import (
+	"fmt"
+	"github.com/deckarep/golang-set"
+	"testing"
+)
+
+func TestResolveViewDependencies(t *testing.T) {
+	views := ViewGraph{
+		&View{Name: "report", Dependencies: []string{"active_users", "user_totals"}},
+		&View{Name: "user_totals", Dependencies: []string{"active_users"}},
+		&View{Name: "active_users"},
+		&View{Name: "posts_view"},
+	}
+	resolved, err := resolveViewDependencies(views)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := []string{"active_users", "posts_view", "user_totals", "report"}
+	for i, view := range resolved {
+		if view.Name != ex[i] {
+			t.Errorf(`Expected '%s', got '%s'`, ex[i], view.Name)
+		}
+	}
+
+	views = ViewGraph{
+		&View{Name: "a", Dependencies: []string{"b"}},
+		&View{Name: "b", Dependencies: []string{"a"}},
+	}
+	if _, err := resolveViewDependencies(views); err == nil {
+		t.Error("Expected a circular dependency error")
+	}
+}
+
+func TestResolveTableRows(t *testing.T) {
+	graph := func() TableGraph {
+		users := NewTable()
+		users.Name = "users"
+		groups := NewTable()
+		groups.Name = "groups"
+		posts := NewTable()
+		posts.Name = "posts"
+		posts.Constraints = []*Constraint{{TableName: "users", ColumnName: "id", ReferencedColumnName: "user_id"}}
+		tags := NewTable()
+		tags.Name = "tags"
+		tags.Constraints = []*Constraint{{TableName: "groups", ColumnName: "id", ReferencedColumnName: "group_id"}}
+		return TableGraph{posts, tags, users, groups}
+	}
+	fn := func(table *Table, cond map[string]mapset.Set) (Rows, error) {
+		rows := Rows{}
+		for i := 1; i <= 3; i++ {
+			id := fmt.Sprintf("%d", i)
+			if set, ok := cond["user_id"]; ok && !set.Contains(id) {
+				continue
+			}
+			if table.Name == "groups" {
+				continue
+			}
+			rows = append(rows, Row{Field{Column: "id", Value: id}, Field{Column: "user_id", Value: id}})
+		}
+		return rows, nil
+	}
+
+	for _, threads := range []int{1, 4} {
+		tables, err := resolveTableConstraints(graph())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = resolveTableRows(tables, threads, fn); err != nil {
+			t.Fatal(err)
+		}
+		ex := map[string]int{"groups": 0, "users": 3, "posts": 3, "tags": 0}
+		order := []string{"groups", "users", "posts", "tags"}
+		for i, table := range tables {
+			if table.Name != order[i] {
+				t.Errorf(`Expected '%s', got '%s'`, order[i], table.Name)
+			}
+			if len(table.Rows) != ex[table.Name] {
+				t.Errorf(`Expected '%d', got '%d'`, ex[table.Name], len(table.Rows))
+			}
+		}
+	}
+}
@@ -1,17 +1,24 @@
 package dbsample
 
 import (
+	"context"
 	gosql "database/sql"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
+	"regexp"
 	"strings"
 )
 
+// serverVariableRegexp matches the names of system variables, which cannot be
+// bound to placeholders.
+var serverVariableRegexp = regexp.MustCompile(`^\w+$`)
+
 // Server...
 type Server struct {
 	conn    *ConnectionArgs
 	args    *DumpArgs
 	db      *gosql.DB
+	tunnel  *SSHTunnel
 	version string
 	major   string
 	minor   string
@@ -28,11 +35,22 @@ func NewServer(conn *ConnectionArgs, args *DumpArgs) *Server {
 
 // Open...
 func (s *Server) Open() error {
+	if s.conn.SSHHost != "" {
+		tunnel, err := NewSSHTunnel(s.conn)
+		if err != nil {
+			return err
+		}
+		tunnel.register()
+		s.tunnel = tunnel
+	}
 	conn, err := gosql.Open(s.conn.Driver, s.conn.dsn())
 	if err != nil {
 		return err
 	}
 	s.db = conn
+	if s.args.Threads > 2 {
+		s.db.SetMaxIdleConns(s.args.Threads)
+	}
 	if err := s.setVersion(); err != nil {
 		return err
 	}
@@ -41,7 +59,13 @@ func (s *Server) Open() error {
 
 // Close...
 func (s *Server) Close() error {
-	return s.db.Close()
+	err := s.db.Close()
+	if s.tunnel != nil {
+		if terr := s.tunnel.Close(); err == nil {
+			err = terr
+		}
+	}
+	return err
 }
 
 // Database returns a new Database instance.
@@ -64,7 +88,10 @@ func (s *Server) Database(name string) (Database, error) {
 // Variable...
 func (s *Server) Variable(v string) (string, error) {
 	var row string
-	rows, err := s.query("SELECT @@%s", v)
+	if !serverVariableRegexp.MatchString(v) {
+		return row, fmt.Errorf("Invalid variable name %q", v)
+	}
+	rows, err := s.query("SELECT @@" + v)
 	if err != nil {
 		return row, err
 	}
@@ -91,20 +118,26 @@ func (s *Server) VersionNumber() string {
 	return fmt.Sprintf("%s%02s%02s", s.major, s.minor, s.rev)
 }
 
-// query...
+// session returns a connection which is not shared with other goroutines. The
+// connection must be closed to return it to the pool.
+func (s *Server) session() (*gosql.Conn, error) {
+	return s.db.Conn(context.Background())
+}
+
+// query runs the statement with the args bound to its placeholders. Values
+// must never be formatted into the statement, and identifiers must be quoted
+// with MySQL5Backtick.
 func (s *Server) query(sql string, args ...interface{}) (*gosql.Rows, error) {
-	sql = fmt.Sprintf(sql, args...)
-	rows, err := s.db.Query(sql)
+	rows, err := s.db.Query(sql, args...)
 	if err != nil {
 		return nil, err
 	}
 	return rows, nil
 }
 
-// exec...
+// exec runs the statement with the args bound to its placeholders.
 func (s *Server) exec(sql string, args ...interface{}) error {
-	sql = fmt.Sprintf(sql, args...)
-	_, err := s.db.Exec(sql)
+	_, err := s.db.Exec(sql, args...)
 	return err
 }
 
@@ -124,20 +157,12 @@ func (s *Server) buildQueryRows(rows *gosql.Rows) (results Rows, err error) {
 		}
 		rows.Scan(rawValues...)
 
-		strValues := make([]string, colNum)
-		for i, v := range rawBytes {
-			if v == nil {
-				strValues[i] = ""
-			} else {
-				strValues[i] = string(v)
-			}
-		}
-
 		fields := make(Row, colNum)
 		for i, c := range columns {
 			fields[i] = Field{
 				Column: c,
-				Value:  strValues[i],
+				Value:  string(rawBytes[i]),
+				Null:   rawBytes[i] == nil,
 			}
 		}
 		results = append(results, fields)
@@ -152,7 +177,7 @@ func (s *Server) selectDatabaseCharSet(name string) (charSet string, collation s
 	if rows, err = s.query(
 		"SELECT `DEFAULT_CHARACTER_SET_NAME`, `DEFAULT_COLLATION_NAME` "+
 			"FROM `INFORMATION_SCHEMA`.`SCHEMATA` "+
-			"WHERE `SCHEMA_NAME` = '%s' "+
+			"WHERE `SCHEMA_NAME` = ? "+
 			"LIMIT 1",
 		name,
 	); err != nil {
@@ -0,0 +1,211 @@
+package dbsample
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/howeyc/gopass"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// sshNetwork is the network the SSH tunnel dialer is registered with the
+// driver as.
+const sshNetwork = "dbsample-ssh"
+
+// sshRegisterOnce guards the registration of the tunnel dialer, which the
+// driver keeps for the life of the process.
+var sshRegisterOnce sync.Once
+
+// sshTunnel is the tunnel used by the registered dialer.
+var sshTunnel *SSHTunnel
+
+// SSHTunnel forwards database connections through an SSH server, so that
+// databases which are only reachable from a bastion host can be sampled.
+type SSHTunnel struct {
+	client *ssh.Client
+	// agent is the connection to the SSH agent, which signs for the client
+	// while it is open, or nil when no agent is used.
+	agent net.Conn
+}
+
+// NewSSHTunnel returns a new *SSHTunnel instance connected to the SSH server of
+// the connection. The server host key must be found in the known hosts file.
+// Keys are taken from the SSH agent when SSH_AUTH_SOCK is set, and from the
+// key file, which is asked for its passphrase when it is encrypted.
+func NewSSHTunnel(conn *ConnectionArgs) (_ *SSHTunnel, err error) {
+	hostKeyCallback, err := knownhosts.New(conn.SSHKnownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("Reading SSH known hosts failed: %s", err)
+	}
+	t := &SSHTunnel{}
+	defer func() {
+		if err != nil && t.agent != nil {
+			t.agent.Close()
+		}
+	}()
+	auth := []ssh.AuthMethod{}
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if a, err := net.Dial("unix", sock); err == nil {
+			t.agent = a
+			auth = append(auth, ssh.PublicKeysCallback(agent.NewClient(a).Signers))
+		}
+	}
+	if conn.SSHKey != "" {
+		signer, err := sshKeySigner(conn.SSHKey)
+		if err != nil {
+			return nil, err
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if len(auth) == 0 {
+		return nil, fmt.Errorf("SSH requires --ssh-key or a running SSH agent")
+	}
+
+	addr := sshAddr(conn.SSHHost)
+	t.client, err = ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:              conn.SSHUser,
+		Auth:              auth,
+		HostKeyCallback:   hostKeyCallback,
+		HostKeyAlgorithms: sshHostKeyAlgorithms(hostKeyCallback, addr),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Connecting to SSH host %s failed: %s", conn.SSHHost, err)
+	}
+	return t, nil
+}
+
+// Dial opens a connection to addr from the SSH server.
+func (t *SSHTunnel) Dial(ctx context.Context, addr string) (net.Conn, error) {
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		c, err := t.client.Dial("tcp", addr)
+		ch <- result{c, err}
+	}()
+	select {
+	case r := <-ch:
+		return r.conn, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-ch; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes the SSH connection and the connection to the SSH agent.
+func (t *SSHTunnel) Close() error {
+	err := t.client.Close()
+	if t.agent != nil {
+		if err2 := t.agent.Close(); err == nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+// register makes the tunnel dial the connections of the sshNetwork protocol.
+func (t *SSHTunnel) register() {
+	sshTunnel = t
+	sshRegisterOnce.Do(func() {
+		mysql.RegisterDialContext(sshNetwork, func(ctx context.Context, addr string) (net.Conn, error) {
+			return sshTunnel.Dial(ctx, addr)
+		})
+	})
+}
+
+// setupSSH sets the defaults of the SSH options when an SSH host is given.
+func (c *ConnectionArgs) setupSSH() error {
+	if c.SSHHost == "" {
+		return nil
+	}
+	if c.Protocol != ProtocolTCP {
+		return fmt.Errorf("The --ssh-host flag requires the tcp protocol")
+	}
+	if c.SSHUser == "" || c.SSHKnownHosts == "" {
+		u, err := user.Current()
+		if err != nil {
+			return err
+		}
+		if c.SSHUser == "" {
+			c.SSHUser = u.Username
+		}
+		if c.SSHKnownHosts == "" {
+			c.SSHKnownHosts = filepath.Join(u.HomeDir, ".ssh", "known_hosts")
+		}
+	}
+	return nil
+}
+
+// sshKeySigner returns the signer of the private key file.
+func sshKeySigner(filename string) (ssh.Signer, error) {
+	pem, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := ssh.ParsePrivateKey(pem)
+	if _, ok := err.(*ssh.PassphraseMissingError); ok {
+		pass, _ := gopass.GetPasswdPrompt(fmt.Sprintf("Enter passphrase for %s: ", filename), false, os.Stdin, os.Stderr)
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, pass)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Reading SSH key %s failed: %s", filename, err)
+	}
+	return signer, nil
+}
+
+// sshHostKeyAlgorithms returns the host key algorithms of the keys the known
+// hosts file holds for addr, so the server is asked for a key which can be
+// checked rather than the key type it prefers. The keys are found by checking
+// a key which is never known, which fails listing the known keys. Nil is
+// returned when the host is not known, which the handshake then reports.
+func sshHostKeyAlgorithms(callback ssh.HostKeyCallback, addr string) []string {
+	placeholder, err := ssh.NewPublicKey(ed25519.PublicKey(make([]byte, ed25519.PublicKeySize)))
+	if err != nil {
+		return nil
+	}
+	keyErr, ok := callback(addr, &net.TCPAddr{IP: net.IPv4zero}, placeholder).(*knownhosts.KeyError)
+	if !ok {
+		return nil
+	}
+	algos := []string{}
+	for _, known := range keyErr.Want {
+		types := []string{known.Key.Type()}
+		if types[0] == ssh.KeyAlgoRSA {
+			// RSA keys sign with SHA-2 on current servers.
+			types = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+		for _, typ := range types {
+			if !stringsContain(algos, typ) {
+				algos = append(algos, typ)
+			}
+		}
+	}
+	if len(algos) == 0 {
+		return nil
+	}
+	return algos
+}
+
+// sshAddr returns the host with the default SSH port when it has none.
+func sshAddr(host string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), "22")
+}
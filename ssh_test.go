@@ -0,0 +1,308 @@
+package dbsample
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSSHTunnel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A stand-in for the database, which echoes what it reads.
+	db, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	go func() {
+		for {
+			c, err := db.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(c, c)
+		}
+	}()
+
+	hostKey, _ := testSSHSigner(t)
+	clientKey, clientPrivateKey := testSSHSigner(t)
+	sshAddr := testSSHServer(t, clientKey.PublicKey(), hostKey)
+
+	keyFile := filepath.Join(dir, "id_ed25519")
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	block, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	line := knownhosts.Line([]string{knownhosts.Normalize(sshAddr)}, hostKey.PublicKey())
+	if err = ioutil.WriteFile(knownHostsFile, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer testRestoreEnv("SSH_AUTH_SOCK")()
+	os.Unsetenv("SSH_AUTH_SOCK")
+	conn := &ConnectionArgs{SSHHost: sshAddr, SSHUser: "dbsample", SSHKey: keyFile, SSHKnownHosts: knownHostsFile}
+	tunnel, err := NewSSHTunnel(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tunnel.Close()
+	c, err := tunnel.Dial(context.Background(), db.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err = c.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 4)
+	if _, err = io.ReadFull(c, buf); err != nil {
+		t.Fatal(err)
+	}
+	if ac := string(buf); ac != "ping" {
+		t.Errorf(`Expected '%s', got '%s'`, "ping", ac)
+	}
+
+	// A host key which is not in the known hosts file must be rejected.
+	otherKey, _ := testSSHSigner(t)
+	conn.SSHHost = testSSHServer(t, clientKey.PublicKey(), otherKey)
+	if _, err = NewSSHTunnel(conn); err == nil {
+		t.Error("Expected an unknown host key error")
+	}
+}
+
+func TestSSHTunnelAgent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	hostKey, _ := testSSHSigner(t)
+	clientKey, clientPrivateKey := testSSHSigner(t)
+	sshAddr := testSSHServer(t, clientKey.PublicKey(), hostKey)
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(sshAddr)}, hostKey.PublicKey())
+	if err = ioutil.WriteFile(knownHostsFile, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// An SSH agent holding the client key, which reports when its connection
+	// is closed.
+	keyring := agent.NewKeyring()
+	if err = keyring.Add(agent.AddedKey{PrivateKey: clientPrivateKey}); err != nil {
+		t.Fatal(err)
+	}
+	sock := filepath.Join(dir, "agent.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	closed := make(chan struct{})
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		agent.ServeAgent(keyring, c)
+		close(closed)
+	}()
+
+	defer testRestoreEnv("SSH_AUTH_SOCK")()
+	os.Setenv("SSH_AUTH_SOCK", sock)
+	tunnel, err := NewSSHTunnel(&ConnectionArgs{SSHHost: sshAddr, SSHUser: "dbsample", SSHKnownHosts: knownHostsFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-closed:
+		t.Fatal("Expected the agent connection to stay open")
+	default:
+	}
+	if err = tunnel.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("Expected the agent connection to be closed")
+	}
+}
+
+func TestSSHHostKeyAlgorithms(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The server prefers its ecdsa key, but only its ed25519 key is known.
+	hostKey, _ := testSSHSigner(t)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ssh.NewSignerFromKey(ecdsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKey, clientPrivateKey := testSSHSigner(t)
+	sshAddr := testSSHServer(t, clientKey.PublicKey(), otherKey, hostKey)
+
+	keyFile := filepath.Join(dir, "id_ed25519")
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	block, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	line := knownhosts.Line([]string{knownhosts.Normalize(sshAddr)}, hostKey.PublicKey())
+	if err = ioutil.WriteFile(knownHostsFile, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ac := sshHostKeyAlgorithms(callback, sshAddr); !reflect.DeepEqual(ac, []string{ssh.KeyAlgoED25519}) {
+		t.Errorf(`Expected '%v', got '%v'`, []string{ssh.KeyAlgoED25519}, ac)
+	}
+	if ac := sshHostKeyAlgorithms(callback, "unknown:22"); ac != nil {
+		t.Errorf(`Expected '%v', got '%v'`, nil, ac)
+	}
+
+	defer testRestoreEnv("SSH_AUTH_SOCK")()
+	os.Unsetenv("SSH_AUTH_SOCK")
+	tunnel, err := NewSSHTunnel(&ConnectionArgs{SSHHost: sshAddr, SSHUser: "dbsample", SSHKey: keyFile, SSHKnownHosts: knownHostsFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tunnel.Close()
+}
+
+func TestSSHAddr(t *testing.T) {
+	tests := map[string]string{
+		"bastion":      "bastion:22",
+		"bastion:2222": "bastion:2222",
+		"::1":          "[::1]:22",
+		"[::1]:2222":   "[::1]:2222",
+	}
+	for host, ex := range tests {
+		if ac := sshAddr(host); ac != ex {
+			t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+		}
+	}
+}
+
+// testRestoreEnv returns a function which restores the environment variable
+// to its current value.
+func testRestoreEnv(key string) func() {
+	value, ok := os.LookupEnv(key)
+	return func() {
+		if ok {
+			os.Setenv(key, value)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+// testSSHSigner returns a new ed25519 key and its signer.
+func testSSHSigner(t *testing.T) (ssh.Signer, ed25519.PrivateKey) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, key
+}
+
+// testSSHServer starts an SSH server with the host keys, which accepts the
+// client key and forwards direct-tcpip channels, and returns its address.
+func testSSHServer(t *testing.T, clientKey ssh.PublicKey, hostKeys ...ssh.Signer) string {
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	for _, hostKey := range hostKeys {
+		config.AddHostKey(hostKey)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		defer l.Close()
+		nc, err := l.Accept()
+		if err != nil {
+			return
+		}
+		_, chans, reqs, err := ssh.NewServerConn(nc, config)
+		if err != nil {
+			return
+		}
+		go ssh.DiscardRequests(reqs)
+		for nch := range chans {
+			if nch.ChannelType() != "direct-tcpip" {
+				nch.Reject(ssh.UnknownChannelType, "")
+				continue
+			}
+			// The payload is the host, port, origin host and origin port.
+			data := nch.ExtraData()
+			n := binary.BigEndian.Uint32(data)
+			host := string(data[4 : 4+n])
+			port := binary.BigEndian.Uint32(data[4+n:])
+			target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
+			if err != nil {
+				nch.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+			ch, creqs, err := nch.Accept()
+			if err != nil {
+				continue
+			}
+			go ssh.DiscardRequests(creqs)
+			go func() {
+				io.Copy(ch, target)
+				ch.CloseWrite()
+			}()
+			go io.Copy(target, ch)
+		}
+	}()
+	return l.Addr().String()
+}
@@ -1,6 +1,10 @@
 package dbsample
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 type (
 	Row          []Field
@@ -16,6 +20,7 @@ type (
 type Field struct {
 	Column string
 	Value  string
+	Null   bool
 }
 
 // Column...
@@ -24,7 +29,26 @@ type Column struct {
 	OrdinalPosition        int
 	Type                   string
 	CharacterMaximumLength int64
+	NumericPrecision       int64
+	NumericScale           int64
 	DataType               string
+	IsNullable             bool
+	// CharSet and Collation are the character set and collation of a string
+	// column, empty for other types.
+	CharSet   string
+	Collation string
+}
+
+// Ordered returns the columns sorted by their ordinal position.
+func (m ColumnMap) Ordered() []*Column {
+	cols := make([]*Column, 0, len(m))
+	for _, col := range m {
+		cols = append(cols, col)
+	}
+	sort.Slice(cols, func(i, j int) bool {
+		return cols[i].OrdinalPosition < cols[j].OrdinalPosition
+	})
+	return cols
 }
 
 // Trigger...
@@ -65,6 +89,7 @@ type View struct {
 	Collation    string
 	SecurityType string
 	Definer      string
+	Dependencies []string
 }
 
 // Table stores the details of a single database table.
@@ -76,18 +101,27 @@ type Table struct {
 	DebugMsgs   []string
 	Columns     ColumnMap
 	Constraints []*Constraint
-	Triggers    TriggerGraph
-	Rows        Rows
+	// SelfReferences are the constraints which reference the table itself.
+	SelfReferences []*Constraint
+	Indexes        []*Index
+	Triggers       TriggerGraph
+	Rows           Rows
+	Level          int
+	// EstimatedRows is the number of rows in the source table estimated by
+	// the server.
+	EstimatedRows int64
 }
 
 // NewTable returns a new *Table instance.
 func NewTable() *Table {
 	return &Table{
-		DebugMsgs:   []string{},
-		Columns:     ColumnMap{},
-		Constraints: []*Constraint{},
-		Triggers:    TriggerGraph{},
-		Rows:        Rows{},
+		DebugMsgs:      []string{},
+		Columns:        ColumnMap{},
+		Constraints:    []*Constraint{},
+		SelfReferences: []*Constraint{},
+		Indexes:        []*Index{},
+		Triggers:       TriggerGraph{},
+		Rows:           Rows{},
 	}
 }
 
@@ -103,4 +137,60 @@ type Constraint struct {
 	TableName            string
 	ColumnName           string
 	ReferencedColumnName string
+	UserDefined          bool
+}
+
+// Index...
+type Index struct {
+	Name    string
+	Unique  bool
+	Columns []string
+}
+
+// rowIndexKey returns the values of the given columns in the row as a single
+// key. Each value is folded the way the collation of its column compares it,
+// so values a unique index treats as duplicates have the same key.
+func rowIndexKey(row Row, columns []string, cols ColumnMap) string {
+	values := make([]string, len(columns))
+	for i, col := range columns {
+		for _, field := range row {
+			if field.Column == col {
+				values[i] = field.Value
+				if c, ok := cols[col]; ok {
+					values[i] = verifyFold(field.Value, c.Collation)
+				}
+			}
+		}
+	}
+	return strings.Join(values, "\000")
+}
+
+// rowValuesEmpty returns whether each of the given columns has an empty value.
+func rowValuesEmpty(values map[string]string, columns []string) bool {
+	for _, col := range columns {
+		if values[col] != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// rowHasNull returns whether any of the given columns is NULL in the row.
+func rowHasNull(row Row, columns []string) bool {
+	for _, field := range row {
+		if field.Null && stringsContain(columns, field.Column) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringsContain...
+func stringsContain(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,156 @@
+package dbsample
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	SSLModeDisabled       = "disabled"
+	SSLModePreferred      = "preferred"
+	SSLModeRequired       = "required"
+	SSLModeVerifyCA       = "verify-ca"
+	SSLModeVerifyIdentity = "verify-identity"
+)
+
+// tlsConfigName is the name the TLS config is registered with the driver as.
+const tlsConfigName = "dbsample"
+
+// SSLModes are the values accepted by --ssl-mode.
+var SSLModes = []string{
+	SSLModeDisabled,
+	SSLModePreferred,
+	SSLModeRequired,
+	SSLModeVerifyCA,
+	SSLModeVerifyIdentity,
+}
+
+// setupTLS registers the TLS config described by the ssl options with the
+// driver, and sets the value of the tls DSN parameter. When no mode is given
+// it defaults to verify-ca when a CA is given, required when a certificate is
+// given, and disabled otherwise. The preferred mode with a CA is verify-ca.
+// @see https://dev.mysql.com/doc/refman/5.7/en/connection-options.html#option_general_ssl-mode
+func (c *ConnectionArgs) setupTLS() error {
+	mode := strings.ToLower(c.SSLMode)
+	switch {
+	case mode != "":
+	case c.SSLCA != "":
+		mode = SSLModeVerifyCA
+	case c.SSLCert != "":
+		mode = SSLModeRequired
+	default:
+		mode = SSLModeDisabled
+	}
+	if !stringsContain(SSLModes, mode) {
+		return fmt.Errorf(`Invalid ssl mode "%s". Must be one of %s`, c.SSLMode, strings.Join(SSLModes, ", "))
+	}
+	if (c.SSLCert == "") != (c.SSLKey == "") {
+		return fmt.Errorf("The --ssl-cert and --ssl-key options must be given together")
+	}
+
+	switch mode {
+	case SSLModeDisabled:
+		if c.SSLCA != "" || c.SSLCert != "" {
+			return fmt.Errorf("The ssl certificate options cannot be used with --ssl-mode=disabled")
+		}
+		c.tls = ""
+		return nil
+	case SSLModePreferred:
+		// The driver falls back to an unencrypted connection only with its
+		// own preferred config, which cannot hold certificates. A CA is only
+		// worth giving when it is checked, so preferred becomes verify-ca, and
+		// a certificate alone is refused rather than silently made required.
+		switch {
+		case c.SSLCA != "":
+			mode = SSLModeVerifyCA
+		case c.SSLCert != "":
+			return fmt.Errorf("The --ssl-mode=preferred option cannot be used with --ssl-cert alone, use --ssl-mode=required or give --ssl-ca")
+		default:
+			c.tls = "preferred"
+			return nil
+		}
+	case SSLModeVerifyCA:
+		if c.SSLCA == "" {
+			return fmt.Errorf("The --ssl-mode=verify-ca option requires --ssl-ca")
+		}
+	}
+
+	cfg, err := c.tlsConfig(mode)
+	if err != nil {
+		return err
+	}
+	if err = mysql.RegisterTLSConfig(tlsConfigName, cfg); err != nil {
+		return err
+	}
+	c.tls = tlsConfigName
+	return nil
+}
+
+// tlsConfig returns the TLS config for the mode. The preferred and required
+// modes encrypt the connection without verifying the server certificate,
+// verify-ca verifies the certificate was signed by the CA, and
+// verify-identity also verifies the certificate matches the host.
+func (c *ConnectionArgs) tlsConfig(mode string) (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if c.SSLCert != "" {
+		cert, err := tls.LoadX509KeyPair(c.SSLCert, c.SSLKey)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if c.SSLCA != "" {
+		pem, err := ioutil.ReadFile(c.SSLCA)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificates found in %s", c.SSLCA)
+		}
+	}
+
+	switch mode {
+	case SSLModePreferred, SSLModeRequired:
+		cfg.InsecureSkipVerify = true
+	case SSLModeVerifyCA:
+		// Go verifies the host name along with the chain, so the chain is
+		// verified by hand.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return tlsVerifyChain(rawCerts, cfg.RootCAs)
+		}
+	case SSLModeVerifyIdentity:
+		cfg.ServerName = c.Host
+	}
+	return cfg, nil
+}
+
+// tlsVerifyChain returns an error when the certificates sent by the server do
+// not chain to one of the roots.
+func tlsVerifyChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("The server did not send a certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
@@ -0,0 +1,116 @@
+package dbsample
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConnectionArgsSetupTLS(t *testing.T) {
+	tests := []struct {
+		conn *ConnectionArgs
+		tls  string
+		err  bool
+	}{
+		{&ConnectionArgs{}, "", false},
+		{&ConnectionArgs{SSLMode: "PREFERRED"}, "preferred", false},
+		{&ConnectionArgs{SSLMode: "bogus"}, "", true},
+		{&ConnectionArgs{SSLMode: "verify-ca"}, "", true},
+		{&ConnectionArgs{SSLMode: "disabled", SSLCA: "ca.pem"}, "", true},
+		{&ConnectionArgs{SSLCert: "cert.pem"}, "", true},
+		{&ConnectionArgs{SSLMode: "preferred", SSLCert: "cert.pem", SSLKey: "key.pem"}, "", true},
+	}
+	for _, test := range tests {
+		err := test.conn.setupTLS()
+		if (err != nil) != test.err {
+			t.Errorf(`Expected error %v, got '%v'`, test.err, err)
+		}
+		if test.conn.tls != test.tls {
+			t.Errorf(`Expected '%s', got '%s'`, test.tls, test.conn.tls)
+		}
+	}
+
+	conn := &ConnectionArgs{User: "u", Protocol: "tcp", Host: "h", Port: "3306", Name: "db", SSLMode: "preferred"}
+	if err := conn.setupTLS(); err != nil {
+		t.Fatal(err)
+	}
+	if ex, ac := "u:@tcp(h:3306)/db?collation=utf8mb4_general_ci&interpolateParams=true&tls=preferred", conn.dsn(); ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+
+	// The preferred mode with a CA verifies the server certificate, which
+	// needs a config of its own.
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ca, _ := testCertificate(t, "ca", nil, nil)
+	caFile := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	conn.SSLCA = caFile
+	if err = conn.setupTLS(); err != nil {
+		t.Fatal(err)
+	}
+	if conn.tls != tlsConfigName {
+		t.Errorf(`Expected '%s', got '%s'`, tlsConfigName, conn.tls)
+	}
+}
+
+func TestTLSVerifyChain(t *testing.T) {
+	ca, caKey := testCertificate(t, "ca", nil, nil)
+	leaf, _ := testCertificate(t, "not-the-host", ca, caKey)
+	other, _ := testCertificate(t, "other", nil, nil)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	if err := tlsVerifyChain([][]byte{leaf.Raw}, roots); err != nil {
+		t.Error(err)
+	}
+	if err := tlsVerifyChain([][]byte{other.Raw}, roots); err == nil {
+		t.Error("Expected an error for a certificate signed by another CA")
+	}
+}
+
+// testCertificate returns a certificate signed by parent, or a self-signed CA
+// certificate when parent is nil.
+func testCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
@@ -0,0 +1,151 @@
+package dbsample
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// verifyExampleValues is the number of orphaned values shown for each foreign
+// key in the report.
+const verifyExampleValues = 5
+
+// OrphanFinding describes the sampled rows of a table whose foreign key value
+// is not found in the sampled rows of the referenced table.
+type OrphanFinding struct {
+	*Constraint
+	// ChildTable is the name of the table which holds the foreign key column.
+	ChildTable string
+	// Rows is the number of orphaned rows.
+	Rows int
+	// Values are the first few orphaned values.
+	Values []string
+	// Filtered is whether a filter changed the child or parent column, which
+	// usually means the filter created the orphans.
+	Filtered bool
+}
+
+// verifyReferences checks the foreign keys of the sampled and filtered tables
+// against the sampled rows of the referenced tables, and returns the foreign
+// keys with orphaned rows. NULL values are not orphans. Values are compared
+// using the collation of the referenced column, see verifyFold.
+func verifyReferences(tables TableGraph) []*OrphanFinding {
+	// The sampled values of each referenced column, by table and column name,
+	// folded by the collation of the column.
+	values := map[string]map[string]map[string]bool{}
+	collations := map[string]map[string]string{}
+	for _, table := range tables {
+		for _, fk := range verifyConstraints(table) {
+			if _, ok := values[fk.TableName]; !ok {
+				values[fk.TableName] = map[string]map[string]bool{}
+				collations[fk.TableName] = map[string]string{}
+			}
+			values[fk.TableName][fk.ColumnName] = map[string]bool{}
+		}
+	}
+	for _, table := range tables {
+		cols := values[table.Name]
+		for name := range cols {
+			if col, ok := table.Columns[name]; ok {
+				collations[table.Name][name] = col.Collation
+			}
+		}
+		for _, row := range table.Rows {
+			for _, field := range row {
+				if set, ok := cols[field.Column]; ok && !field.Null {
+					set[verifyFold(field.Value, collations[table.Name][field.Column])] = true
+				}
+			}
+		}
+	}
+
+	findings := []*OrphanFinding{}
+	for _, table := range tables {
+		for _, fk := range verifyConstraints(table) {
+			parents := values[fk.TableName][fk.ColumnName]
+			collation := collations[fk.TableName][fk.ColumnName]
+			f := &OrphanFinding{
+				Constraint: fk,
+				ChildTable: table.Name,
+				Values:     []string{},
+				Filtered:   Filters.HasFilter(table.Name, fk.ReferencedColumnName) || Filters.HasFilter(fk.TableName, fk.ColumnName),
+			}
+			for _, row := range table.Rows {
+				for _, field := range row {
+					if field.Column != fk.ReferencedColumnName || field.Null || parents[verifyFold(field.Value, collation)] {
+						continue
+					}
+					f.Rows++
+					if len(f.Values) < verifyExampleValues {
+						f.Values = append(f.Values, field.Value)
+					}
+				}
+			}
+			if f.Rows > 0 {
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// writeVerifyReport writes the findings of a verification in a human readable
+// format.
+func writeVerifyReport(w io.Writer, findings []*OrphanFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "Verify: every sampled foreign key value references a sampled row.")
+		return
+	}
+	fmt.Fprintf(w, "Verify: %d foreign key(s) reference rows missing from the sample.\n", len(findings))
+	for _, f := range findings {
+		values := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			values[i] = MySQL5Quote(v)
+		}
+		if f.Rows > len(f.Values) {
+			values = append(values, "...")
+		}
+		fmt.Fprintf(
+			w,
+			"  %s.%s -> %s.%s: %d orphaned row(s), e.g. %s",
+			f.ChildTable,
+			f.ReferencedColumnName,
+			f.TableName,
+			f.ColumnName,
+			f.Rows,
+			strings.Join(values, ", "),
+		)
+		if f.UserDefined {
+			fmt.Fprint(w, " (--constraint)")
+		}
+		if f.Filtered {
+			fmt.Fprint(w, " (filtered)")
+		}
+		fmt.Fprint(w, "\n")
+	}
+}
+
+// verifyFold returns the value the way a column with the collation compares
+// it. Case insensitive collations fold the case, and collations which pad with
+// spaces, which is all of them but the binary, NO PAD and 0900 collations,
+// ignore trailing spaces. Accents are still compared exactly, so a child value
+// which only differs from its parent by an accent is reported as an orphan even
+// by accent insensitive collations.
+func verifyFold(value, collation string) string {
+	if collation == "" || collation == "binary" {
+		return value
+	}
+	if !strings.Contains(collation, "_0900_") && !strings.Contains(collation, "_nopad_") {
+		value = strings.TrimRight(value, " ")
+	}
+	if strings.HasSuffix(collation, "_ci") {
+		value = strings.ToLower(strings.ToUpper(value))
+	}
+	return value
+}
+
+// verifyConstraints returns the constraints and self references of the table.
+func verifyConstraints(table *Table) []*Constraint {
+	fks := append([]*Constraint{}, table.Constraints...)
+	return append(fks, table.SelfReferences...)
+}
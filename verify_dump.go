@@ -0,0 +1,374 @@
+package dbsample
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	gms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// verifyDumpDatabase is the database the statements of a dump run in until the
+// dump selects one with USE.
+const verifyDumpDatabase = "dbsample"
+
+// verifyDumpChecksumRegexp matches the row count and checksum which the dumper
+// writes above the INSERT statements of each table.
+var verifyDumpChecksumRegexp = regexp.MustCompile("(?m)^-- Dumping data for table `((?:[^`]|``)+)`\\r?\\n-- Rows: (\\d+), Checksum: ([0-9a-f]{8})\\r?$")
+
+// verifyDumpInsertRegexp matches the start of an INSERT statement.
+var verifyDumpInsertRegexp = regexp.MustCompile(`(?i)^INSERT\s+INTO\s+`)
+
+// DumpStatement is a single statement read from a dump.
+type DumpStatement struct {
+	SQL  string
+	Line int
+}
+
+// DumpTableVerification compares the rows of a table restored from a dump with
+// the rows the dumper recorded writing.
+type DumpTableVerification struct {
+	Name string
+	// Recorded is whether the dump records the row count and checksum of the
+	// table. Dumps written by older versions do not.
+	Recorded         bool
+	ExpectedRows     int
+	ExpectedChecksum uint32
+	// Rows is the row count of the restored table, and Checksum the checksum
+	// of its rows, see MySQL5Dumper.tableChecksum.
+	Rows     int
+	Checksum uint32
+}
+
+// OK returns whether the rows restored match the rows recorded.
+func (v *DumpTableVerification) OK() bool {
+	return !v.Recorded || (v.Rows == v.ExpectedRows && v.Checksum == v.ExpectedChecksum)
+}
+
+// DumpVerification is the result of verifying a dump.
+type DumpVerification struct {
+	Statements int
+	// Database is the database the dump was restored into.
+	Database string
+	Tables   []*DumpTableVerification
+	// Complete is whether the dump ends with the "Dump completed" line.
+	Complete bool
+}
+
+// VerifyDump restores the SQL dump in filename, which may be compressed, into
+// an in-memory MySQL compatible engine, and writes a report of the
+// verification to w. An error is returned when a statement fails, or the rows
+// of a table do not match the rows the dumper recorded writing.
+func VerifyDump(w io.Writer, filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	switch resultCompression(filename) {
+	case CompressGzip:
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case CompressZstd:
+		zr, err := zstd.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	}
+	sql, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	v, err := verifyDump(string(sql))
+	if err != nil {
+		return fmt.Errorf("Verify %s failed: %s", filename, err)
+	}
+	failed := 0
+	fmt.Fprintf(w, "Verify %s: %d statement(s) restored into %s.\n", filename, v.Statements, MySQL5Backtick(v.Database))
+	for _, t := range v.Tables {
+		switch {
+		case !t.Recorded:
+			fmt.Fprintf(w, "  %s: %d rows, no recorded row count to compare.\n", MySQL5Backtick(t.Name), t.Rows)
+		case t.OK():
+			fmt.Fprintf(w, "  %s: %d rows, checksum %08x ok.\n", MySQL5Backtick(t.Name), t.Rows, t.Checksum)
+		default:
+			failed++
+			fmt.Fprintf(w, "  %s: %d of %d rows, checksum %08x, expected %08x.\n", MySQL5Backtick(t.Name), t.Rows, t.ExpectedRows, t.Checksum, t.ExpectedChecksum)
+		}
+	}
+	if !v.Complete {
+		fmt.Fprintln(w, "  Warning: The dump has no \"Dump completed\" line and may be truncated.")
+	}
+	if failed > 0 {
+		return fmt.Errorf("Verify %s failed: %d table(s) do not match the rows recorded by the dump.", filename, failed)
+	}
+	return nil
+}
+
+// verifyDump executes each statement of the dump in an in-memory database,
+// then counts the rows of each restored table with SELECT COUNT(*), and
+// checksums the columns the INSERT statements wrote the same way as
+// MySQL5Dumper.tableChecksum.
+func verifyDump(sql string) (*DumpVerification, error) {
+	stmts, err := splitMySQL5Statements(sql)
+	if err != nil {
+		return nil, err
+	}
+	v := &DumpVerification{
+		Statements: len(stmts),
+		Tables:     []*DumpTableVerification{},
+		Complete:   strings.Contains(sql, "\n-- Dump completed on "),
+	}
+
+	// The recorded checksums name the table as written in a comment.
+	recorded := map[string]*DumpTableVerification{}
+	for _, m := range verifyDumpChecksumRegexp.FindAllStringSubmatch(sql, -1) {
+		t := &DumpTableVerification{Name: strings.Replace(m[1], "``", "`", -1), Recorded: true}
+		t.ExpectedRows, _ = strconv.Atoi(m[2])
+		sum, _ := strconv.ParseUint(m[3], 16, 32)
+		t.ExpectedChecksum = uint32(sum)
+		recorded[t.Name] = t
+		v.Tables = append(v.Tables, t)
+	}
+
+	pro := memory.NewDBProvider(memory.NewDatabase(verifyDumpDatabase))
+	engine := sqle.NewDefault(pro)
+	ctx := gms.NewContext(context.Background(), gms.WithSession(memory.NewSession(gms.NewBaseSession(), pro)))
+	ctx.SetCurrentDatabase(verifyDumpDatabase)
+	columns := map[string][]string{}
+	for _, stmt := range stmts {
+		if _, err = verifyQuery(engine, ctx, stmt.SQL); err != nil {
+			return nil, fmt.Errorf("line %d: %s", stmt.Line, err)
+		}
+		if verifyDumpInsertRegexp.MatchString(stmt.SQL) {
+			name, cols, err := parseMySQL5InsertColumns(stmt.SQL)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", stmt.Line, err)
+			}
+			if _, ok := columns[name]; !ok {
+				columns[name] = cols
+			}
+		}
+	}
+
+	// The rows are read the way the dumper reads them, in the character set
+	// of each column.
+	if _, err = verifyQuery(engine, ctx, "SET character_set_results = 'binary'"); err != nil {
+		return nil, err
+	}
+	v.Database = ctx.GetCurrentDatabase()
+	db, err := pro.Database(ctx, v.Database)
+	if err != nil {
+		return nil, err
+	}
+	names, err := db.GetTableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t, ok := recorded[MySQL5Comment(name)]
+		if !ok {
+			t = &DumpTableVerification{}
+			v.Tables = append(v.Tables, t)
+		}
+		t.Name = name
+		if err = verifyTable(engine, ctx, t, columns[name]); err != nil {
+			return nil, fmt.Errorf("Reading the restored table %s failed: %s", MySQL5Backtick(name), err)
+		}
+	}
+	return v, nil
+}
+
+// verifyTable sets the row count of the restored table, and the checksum of
+// the columns, which are those the dump inserted into.
+func verifyTable(engine *sqle.Engine, ctx *gms.Context, t *DumpTableVerification, columns []string) error {
+	rows, err := verifyQuery(engine, ctx, "SELECT COUNT(*) FROM "+MySQL5Backtick(t.Name))
+	if err != nil {
+		return err
+	}
+	if t.Rows, err = strconv.Atoi(rows[0][0].Value); err != nil {
+		return err
+	}
+	t.Checksum = 0
+	if len(columns) == 0 {
+		return nil
+	}
+	cols := append([]string{}, columns...)
+	rows, err = verifyQuery(engine, ctx, "SELECT "+MySQL5JoinColumns(cols)+" FROM "+MySQL5Backtick(t.Name))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		t.Checksum += mysql5RowChecksum(row)
+	}
+	return nil
+}
+
+// verifyQuery executes the query in the engine, and returns the rows of the
+// result as the text values the MySQL protocol sends, which are the values
+// the dumper reads.
+func verifyQuery(engine *sqle.Engine, ctx *gms.Context, query string) ([]Row, error) {
+	schema, iter, _, err := engine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := gms.RowIterToRows(ctx, iter)
+	if err != nil {
+		return nil, err
+	}
+	if types.IsOkResultSchema(schema) {
+		return nil, nil
+	}
+	result := make([]Row, len(rows))
+	for i, row := range rows {
+		for j, value := range row {
+			val, err := schema[j].Type.SQL(ctx, nil, value)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = append(result[i], Field{Column: schema[j].Name, Value: val.ToString(), Null: val.IsNull()})
+		}
+	}
+	return result, nil
+}
+
+// splitMySQL5Statements splits a dump into statements the way the mysql client
+// does. Comments are removed, except for /*! version comments which the
+// server executes, and DELIMITER lines change the statement delimiter. An
+// error is returned for unterminated strings, comments and statements.
+func splitMySQL5Statements(sql string) ([]*DumpStatement, error) {
+	stmts := []*DumpStatement{}
+	delimiter := ";"
+	buf := &strings.Builder{}
+	line, start := 1, 0
+	inVersion := false
+
+	// write appends text to the current statement, which starts on the line of
+	// the first text which is not white space.
+	write := func(text string) {
+		if buf.Len() == 0 && strings.TrimSpace(text) == "" {
+			return
+		}
+		if buf.Len() == 0 {
+			start = line
+		}
+		buf.WriteString(text)
+	}
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		if buf.Len() == 0 && (i == 0 || sql[i-1] == '\n') {
+			end := strings.IndexByte(sql[i:], '\n')
+			if end == -1 {
+				end = len(sql) - i
+			}
+			text := strings.TrimSpace(sql[i : i+end])
+			if len(text) > 10 && strings.EqualFold(text[:10], "DELIMITER ") {
+				delimiter = strings.TrimSpace(text[10:])
+				i += end
+				continue
+			}
+		}
+
+		switch {
+		case c == '\n':
+			write("\n")
+			line++
+			i++
+		case !inVersion && strings.HasPrefix(sql[i:], delimiter):
+			if buf.Len() > 0 {
+				stmts = append(stmts, &DumpStatement{SQL: strings.TrimSpace(buf.String()), Line: start})
+				buf.Reset()
+			}
+			i += len(delimiter)
+		case c == '#' || strings.HasPrefix(sql[i:], "--") && (i+2 == len(sql) || strings.IndexByte(" \t\r\n", sql[i+2]) != -1):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end == -1 {
+				end = len(sql) - i
+			}
+			i += end
+		case strings.HasPrefix(sql[i:], "/*!"):
+			write("/*!")
+			inVersion = true
+			i += 3
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("line %d: Unterminated comment.", line)
+			}
+			line += strings.Count(sql[i:i+2+end], "\n")
+			i += end + 4
+		case inVersion && strings.HasPrefix(sql[i:], "*/"):
+			write("*/")
+			inVersion = false
+			i += 2
+		case c == '\'' || c == '"' || c == '`':
+			end, err := mysql5QuotedEnd(sql, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err)
+			}
+			write(sql[i:end])
+			line += strings.Count(sql[i:end], "\n")
+			i = end
+		default:
+			write(sql[i : i+1])
+			i++
+		}
+	}
+	if inVersion {
+		return nil, fmt.Errorf("line %d: Unterminated version comment.", start)
+	}
+	if buf.Len() > 0 {
+		return nil, fmt.Errorf("line %d: Statement is not terminated with %s.", start, delimiter)
+	}
+	return stmts, nil
+}
+
+// parseMySQL5InsertColumns parses the start of an INSERT statement written by
+// MySQL5Dumper, and returns the table name and the columns inserted into.
+func parseMySQL5InsertColumns(sql string) (string, []string, error) {
+	i := len(verifyDumpInsertRegexp.FindString(sql))
+	name, i, err := parseMySQL5Identifier(sql, i)
+	if err != nil {
+		return "", nil, err
+	}
+	i = skipMySQL5Space(sql, i)
+	if i >= len(sql) || sql[i] != '(' {
+		return "", nil, fmt.Errorf("Expected the column list of the INSERT into %s.", MySQL5Backtick(name))
+	}
+	columns := []string{}
+	for {
+		var col string
+		if col, i, err = parseMySQL5Identifier(sql, skipMySQL5Space(sql, i+1)); err != nil {
+			return "", nil, err
+		}
+		columns = append(columns, col)
+		i = skipMySQL5Space(sql, i)
+		if i < len(sql) && sql[i] == ')' {
+			return name, columns, nil
+		}
+		if i >= len(sql) || sql[i] != ',' {
+			return "", nil, fmt.Errorf("Expected , or ) in the column list of %s.", MySQL5Backtick(name))
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package dbsample
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSplitMySQL5Statements(t *testing.T) {
+	sql := "-- Comment; with a delimiter\n" +
+		"/*!40101 SET NAMES utf8 */;\n" +
+		"/* comment; */ INSERT INTO `a;b` VALUES('x;\\'y', \"z\");\n" +
+		"# comment;\n" +
+		"DELIMITER ;;\n" +
+		"CREATE PROCEDURE `p`() BEGIN\n  SELECT 1; SELECT 2;\nEND ;;\n" +
+		"DELIMITER ;\n" +
+		"SELECT 3;\n"
+	stmts, err := splitMySQL5Statements(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := []string{
+		"/*!40101 SET NAMES utf8 */",
+		"INSERT INTO `a;b` VALUES('x;\\'y', \"z\")",
+		"CREATE PROCEDURE `p`() BEGIN\n  SELECT 1; SELECT 2;\nEND",
+		"SELECT 3",
+	}
+	if len(stmts) != len(ex) {
+		t.Fatalf(`Expected '%d', got '%d'`, len(ex), len(stmts))
+	}
+	for i, stmt := range stmts {
+		if stmt.SQL != ex[i] {
+			t.Errorf(`Expected '%s', got '%s'`, ex[i], stmt.SQL)
+		}
+	}
+	if stmts[2].Line != 6 {
+		t.Errorf(`Expected '%d', got '%d'`, 6, stmts[2].Line)
+	}
+
+	tests := map[string]string{
+		"SELECT 'a;\n":          "line 1: Unterminated ' quoted value.",
+		"SELECT 1;\n/* a\n":     "line 2: Unterminated comment.",
+		"SELECT 1;\nSELECT 2\n": "line 2: Statement is not terminated with ;.",
+	}
+	for sql, ex := range tests {
+		if _, err := splitMySQL5Statements(sql); err == nil || err.Error() != ex {
+			t.Errorf(`Expected '%s', got '%v'`, ex, err)
+		}
+	}
+}
+
+func TestVerifyDump(t *testing.T) {
+	for _, ext := range []bool{false, true} {
+		args := &DumpArgs{ExtendedInsert: ext, Views: true}
+		db := newTestDatabase(args)
+		// A table with a latin1 column, and a column the policy dropped, which
+		// the dump does not insert into.
+		table := NewTable()
+		table.Name = "us`e\nrs; -- "
+		table.CharSet = mysql5ConnectionCharSet
+		table.Collation = mysql5ConnectionCollation
+		table.CreateSQL = "CREATE TABLE " + MySQL5Backtick(table.Name) + " (\n  `id` int(11) NOT NULL,\n  `name` varchar(20) DEFAULT NULL,\n" +
+			"  `latin` varchar(20) CHARACTER SET latin1 DEFAULT NULL,\n" +
+			"  `geo` point DEFAULT NULL,\n  `secret` varchar(20) DEFAULT NULL,\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+		table.Columns = ColumnMap{
+			"id":    &Column{Name: "id", OrdinalPosition: 1, DataType: "int"},
+			"name":  &Column{Name: "name", OrdinalPosition: 2, DataType: "varchar"},
+			"latin": &Column{Name: "latin", OrdinalPosition: 3, DataType: "varchar", CharSet: "latin1"},
+			"geo":   &Column{Name: "geo", OrdinalPosition: 4, DataType: "point"},
+		}
+		point := "\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\x00\x40"
+		table.Rows = Rows{
+			Row{Field{Column: "id", Value: "1"}, Field{Column: "name", Value: "a'), ('b"}, Field{Column: "latin", Value: "caf\xe9"}, Field{Column: "geo", Value: point}},
+			Row{Field{Column: "id", Value: "2"}, Field{Column: "name", Value: "NULL"}, Field{Column: "latin", Value: ""}, Field{Column: "geo", Null: true}},
+		}
+		db.tables = append(db.tables, table)
+
+		// A latin1 table, which is created with latin1 as the client
+		// character set.
+		latin := NewTable()
+		latin.Name = "tags"
+		latin.CharSet = "latin1"
+		latin.Collation = "latin1_swedish_ci"
+		latin.CreateSQL = "CREATE TABLE `tags` (\n  `id` int(11) NOT NULL,\n  `name` varchar(20) DEFAULT 'caf\xc3\xa9',\n  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=latin1"
+		latin.Columns = ColumnMap{
+			"id":   &Column{Name: "id", OrdinalPosition: 1, DataType: "int"},
+			"name": &Column{Name: "name", OrdinalPosition: 2, DataType: "varchar", CharSet: "latin1"},
+		}
+		latin.Rows = Rows{
+			Row{Field{Column: "id", Value: "1"}, Field{Column: "name", Value: "caf\xe9"}},
+		}
+		db.tables = append(db.tables, latin)
+
+		buf := &bytes.Buffer{}
+		if err := NewMySQL5Dumper(args).Dump(buf, db); err != nil {
+			t.Fatal(err)
+		}
+		sql := buf.String()
+		if !strings.Contains(sql, "SET collation_connection  = latin1_swedish_ci */;\nCREATE TABLE `tags` (\n  `id` int(11) NOT NULL,\n  `name` varchar(20) DEFAULT 'caf\xe9'") {
+			t.Errorf(`Expected '%s' in '%s'`, "the latin1 CREATE TABLE of `tags`", sql)
+		}
+		v, err := verifyDump(sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.Database != "blog" || len(v.Tables) != len(db.tables) || !v.Complete {
+			t.Errorf(`Expected '%s', got '%+v'`, "6 tables restored into blog", v)
+		}
+		for _, tv := range v.Tables {
+			if !tv.Recorded || !tv.OK() {
+				t.Errorf(`Expected '%s', got '%+v'`, "matching rows", tv)
+			}
+		}
+
+		tampered := strings.Replace(sql, "(2, 'NULL'", "(2, NULL", 1)
+		if tampered == sql {
+			t.Fatalf(`Expected '%s' in '%s'`, "(2, 'NULL'", sql)
+		}
+		if v, err = verifyDump(tampered); err != nil {
+			t.Fatal(err)
+		}
+		if tv := v.Tables[len(v.Tables)-2]; tv.Name != table.Name || tv.OK() {
+			t.Errorf(`Expected '%s', got '%+v'`, "a checksum mismatch", tv)
+		}
+	}
+
+	tests := map[string]string{
+		"CREATE TABLE `users` (`id` int);\nINSERT INTO `users` (`id`) VALUES (1);\nINSERT INTO `posts` (`id`) VALUES (1);\n": "line 3: table not found: posts",
+		"CREATE TABLE `users` (`id` int);\nINSERT INTO `users` VALUES (1);\n":                                                "line 2: Expected the column list of the INSERT into `users`.",
+	}
+	for sql, ex := range tests {
+		if _, err := verifyDump(sql); err == nil || err.Error() != ex {
+			t.Errorf(`Expected '%s', got '%v'`, ex, err)
+		}
+	}
+
+	sql := "--\n-- Dumping data for table `users`\n-- Rows: 2, Checksum: 00000000\n--\n\n" +
+		"CREATE TABLE `users` (`id` int);\nINSERT INTO `users` (`id`) VALUES (1);\n"
+	v, err := verifyDump(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Tables) != 1 || v.Tables[0].Rows != 1 || v.Tables[0].OK() || v.Complete {
+		t.Errorf(`Expected '%s', got '%+v'`, "1 of 2 rows", v.Tables[0])
+	}
+}
@@ -0,0 +1,91 @@
+package dbsample
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVerifyReferences(t *testing.T) {
+	users := NewTable()
+	users.Name = "users"
+	users.Rows = Rows{
+		Row{Field{Column: "id", Value: "1"}},
+		Row{Field{Column: "id", Value: "2"}},
+	}
+	posts := NewTable()
+	posts.Name = "posts"
+	posts.Constraints = []*Constraint{{TableName: "users", ColumnName: "id", ReferencedColumnName: "user_id", UserDefined: true}}
+	posts.SelfReferences = []*Constraint{{TableName: "posts", ColumnName: "id", ReferencedColumnName: "parent_id"}}
+	posts.Rows = Rows{
+		Row{Field{Column: "id", Value: "10"}, Field{Column: "user_id", Value: "1"}, Field{Column: "parent_id", Null: true}},
+		Row{Field{Column: "id", Value: "11"}, Field{Column: "user_id", Value: "3"}, Field{Column: "parent_id", Value: "10"}},
+		Row{Field{Column: "id", Value: "12"}, Field{Column: "user_id", Null: true}, Field{Column: "parent_id", Value: "9"}},
+	}
+
+	buf := &bytes.Buffer{}
+	writeVerifyReport(buf, verifyReferences(TableGraph{users, posts}))
+	ex := "Verify: 2 foreign key(s) reference rows missing from the sample.\n" +
+		"  posts.user_id -> users.id: 1 orphaned row(s), e.g. '3' (--constraint)\n" +
+		"  posts.parent_id -> posts.id: 1 orphaned row(s), e.g. '9'\n"
+	if ac := buf.String(); ac != ex {
+		t.Errorf(`Expected '%s', got '%s'`, ex, ac)
+	}
+
+	posts.Rows = posts.Rows[:1]
+	if findings := verifyReferences(TableGraph{users, posts}); len(findings) != 0 {
+		t.Errorf(`Expected '%d', got '%d'`, 0, len(findings))
+	}
+}
+
+func TestVerifyReferencesCollation(t *testing.T) {
+	tests := []struct {
+		collation string
+		orphans   int
+	}{
+		{"", 2},
+		{"utf8mb4_bin", 1},
+		{"utf8mb4_general_ci", 0},
+		{"utf8mb4_0900_ai_ci", 1},
+	}
+	for _, test := range tests {
+		users := NewTable()
+		users.Name = "users"
+		users.Columns = ColumnMap{"email": &Column{Name: "email", Collation: test.collation}}
+		users.Rows = Rows{Row{Field{Column: "email", Value: "a@example.com"}}}
+		posts := NewTable()
+		posts.Name = "posts"
+		posts.Constraints = []*Constraint{{TableName: "users", ColumnName: "email", ReferencedColumnName: "user_email"}}
+		posts.Rows = Rows{
+			Row{Field{Column: "user_email", Value: "A@Example.com"}},
+			Row{Field{Column: "user_email", Value: "a@example.com "}},
+		}
+
+		orphans := 0
+		for _, f := range verifyReferences(TableGraph{users, posts}) {
+			orphans += f.Rows
+		}
+		if orphans != test.orphans {
+			t.Errorf(`Expected %d orphans with collation '%s', got %d`, test.orphans, test.collation, orphans)
+		}
+	}
+}
+
+func TestVerifyFold(t *testing.T) {
+	tests := []struct {
+		value     string
+		collation string
+		ex        string
+	}{
+		{"Abc ", "", "Abc "},
+		{"Abc ", "binary", "Abc "},
+		{"Abc ", "latin1_bin", "Abc"},
+		{"Abc ", "latin1_swedish_ci", "abc"},
+		{"Abc ", "utf8mb4_0900_as_cs", "Abc "},
+		{"Straße ", "utf8mb4_0900_ai_ci", "straße "},
+	}
+	for _, test := range tests {
+		if ac := verifyFold(test.value, test.collation); ac != test.ex {
+			t.Errorf(`Expected '%s', got '%s'`, test.ex, ac)
+		}
+	}
+}
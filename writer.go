@@ -0,0 +1,146 @@
+package dbsample
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	CompressAuto = "auto"
+	CompressNone = "none"
+	CompressGzip = "gzip"
+	CompressZstd = "zstd"
+)
+
+// ResultWriter writes a dump to stdout or to a file, optionally compressing
+// the output. Files are written to a temporary file which is renamed when the
+// writer is closed, so a failed dump never leaves a partial file behind.
+type ResultWriter struct {
+	filename string
+	file     *os.File
+	w        io.Writer
+	closer   io.Closer
+}
+
+// NewResultWriter returns a new *ResultWriter instance which writes to filename,
+// or to stdout when filename is empty. The compress argument is one of the
+// Compress constants, where CompressAuto chooses the compression from the
+// file extension, e.g. ".sql.gz" or ".sql.zst".
+func NewResultWriter(filename, compress string) (*ResultWriter, error) {
+	if compress == "" || compress == CompressAuto {
+		compress = resultCompression(filename)
+	}
+	rw := &ResultWriter{
+		filename: filename,
+		w:        os.Stdout,
+	}
+	if filename != "" {
+		file, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".")
+		if err != nil {
+			return nil, err
+		}
+		rw.file = file
+		rw.w = file
+	}
+
+	switch compress {
+	case CompressNone:
+	case CompressGzip:
+		gz := gzip.NewWriter(rw.w)
+		rw.w, rw.closer = gz, gz
+	case CompressZstd:
+		zw, err := zstd.NewWriter(rw.w)
+		if err != nil {
+			rw.Abort()
+			return nil, err
+		}
+		rw.w, rw.closer = zw, zw
+	default:
+		rw.Abort()
+		return nil, fmt.Errorf(`Invalid compression "%s"`, compress)
+	}
+	return rw, nil
+}
+
+// Write...
+func (rw *ResultWriter) Write(p []byte) (int, error) {
+	return rw.w.Write(p)
+}
+
+// Close flushes the output and moves the temporary file into place.
+func (rw *ResultWriter) Close() error {
+	if rw.closer != nil {
+		err := rw.closer.Close()
+		rw.closer = nil
+		if err != nil {
+			rw.Abort()
+			return err
+		}
+	}
+	if rw.file == nil {
+		return nil
+	}
+	if err := rw.file.Chmod(0644); err != nil {
+		rw.Abort()
+		return err
+	}
+	if err := rw.file.Sync(); err != nil {
+		rw.Abort()
+		return err
+	}
+	if err := rw.file.Close(); err != nil {
+		os.Remove(rw.file.Name())
+		return err
+	}
+	return os.Rename(rw.file.Name(), rw.filename)
+}
+
+// Abort discards the output. The result file is left untouched.
+func (rw *ResultWriter) Abort() {
+	if rw.closer != nil {
+		rw.closer.Close()
+		rw.closer = nil
+	}
+	if rw.file != nil {
+		rw.file.Close()
+		os.Remove(rw.file.Name())
+	}
+}
+
+// resultCompression returns the compression used for the file extension.
+func resultCompression(filename string) string {
+	switch {
+	case strings.HasSuffix(filename, ".gz"):
+		return CompressGzip
+	case strings.HasSuffix(filename, ".zst"):
+		return CompressZstd
+	}
+	return CompressNone
+}
+
+// compressExtension returns the file extension used for the compression.
+func compressExtension(compress string) string {
+	switch compress {
+	case CompressGzip:
+		return ".gz"
+	case CompressZstd:
+		return ".zst"
+	}
+	return ""
+}
+
+// safeFileName returns an object name which is safe to use as a file name.
+func safeFileName(name string) string {
+	return strings.NewReplacer(
+		"%", "%25",
+		"/", "%2F",
+		"\\", "%5C",
+		"\x00", "%00",
+	).Replace(name)
+}
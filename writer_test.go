@@ -0,0 +1,72 @@
+package dbsample
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResultWriterGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "dump.sql.gz")
+	w, err := NewResultWriter(filename, CompressAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("SELECT 1;\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		t.Error("Expected the result file to not exist before Close")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "SELECT 1;\n" {
+		t.Errorf(`Expected 'SELECT 1;', got '%s'`, b)
+	}
+}
+
+func TestResultWriterAbort(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbsample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "dump.sql.zst")
+	w, err := NewResultWriter(filename, CompressAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("SELECT 1;\n"))
+	w.Abort()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected no files after Abort, got %d", len(files))
+	}
+}